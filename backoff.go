@@ -0,0 +1,101 @@
+package form3client
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff computes the interval to wait before retrying a request.
+//
+// retry: number of the retry about to be made, starting at 1.
+//
+// previous: interval returned for the previous retry, zero before the first one.
+type Backoff interface {
+	Next(retry int, previous time.Duration) time.Duration
+}
+
+// BackoffFunc allows the use of ordinary functions as Backoff.
+type BackoffFunc func(retry int, previous time.Duration) time.Duration
+
+// Next calls f(retry, previous).
+func (f BackoffFunc) Next(retry int, previous time.Duration) time.Duration {
+	return f(retry, previous)
+}
+
+// ExponentialBackoff waits base * 2^(retry-1) plus a random jitter in [0, maxJitter),
+// never exceeding max. A max of zero means no maximum interval.
+//
+// It's the Backoff used by NewRetryDoer and the Retries option.
+func ExponentialBackoff(base, maxJitter, max time.Duration) Backoff {
+	return BackoffFunc(func(retry int, previous time.Duration) time.Duration {
+		interval := exponential(base, retry)
+		if maxJitter > 0 && interval < math.MaxInt64-maxJitter {
+			interval += time.Duration(rand.Int63n(int64(maxJitter)))
+		}
+
+		return capInterval(interval, max)
+	})
+}
+
+// FullJitterBackoff waits a random interval in [0, base * 2^(retry-1)), never
+// exceeding max. A max of zero means no maximum interval.
+func FullJitterBackoff(base, max time.Duration) Backoff {
+	return BackoffFunc(func(retry int, previous time.Duration) time.Duration {
+		interval := capInterval(exponential(base, retry), max)
+		if interval <= 0 {
+			return 0
+		}
+
+		return time.Duration(rand.Int63n(int64(interval)))
+	})
+}
+
+// DecorrelatedJitterBackoff waits a random interval in [base, previous * 3), where
+// the first retry starts from base, never exceeding max. A max of zero means no
+// maximum interval.
+func DecorrelatedJitterBackoff(base, max time.Duration) Backoff {
+	return BackoffFunc(func(retry int, previous time.Duration) time.Duration {
+		if previous < base {
+			previous = base
+		}
+
+		interval := base
+		if upper := previous * 3; upper > base {
+			interval += time.Duration(rand.Int63n(int64(upper - base)))
+		}
+
+		return capInterval(interval, max)
+	})
+}
+
+// ConstantBackoff waits the same interval before every retry.
+func ConstantBackoff(interval time.Duration) Backoff {
+	return BackoffFunc(func(retry int, previous time.Duration) time.Duration {
+		return interval
+	})
+}
+
+// CappedBackoff limits the intervals of any Backoff to max.
+func CappedBackoff(backoff Backoff, max time.Duration) Backoff {
+	return BackoffFunc(func(retry int, previous time.Duration) time.Duration {
+		return capInterval(backoff.Next(retry, previous), max)
+	})
+}
+
+func exponential(base time.Duration, retry int) time.Duration {
+	interval := float64(base) * math.Exp2(float64(retry-1))
+	if interval >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return time.Duration(interval)
+}
+
+func capInterval(interval, max time.Duration) time.Duration {
+	if max > 0 && interval > max {
+		return max
+	}
+
+	return interval
+}
@@ -0,0 +1,156 @@
+package form3client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	f3Client "form3-client-library"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoff_WhenRetrying_ThenDoublesIntervalWithinJitterAndMax(t *testing.T) {
+	backoff := f3Client.ExponentialBackoff(100*time.Millisecond, 10*time.Millisecond, time.Second)
+
+	tests := []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{1, 100 * time.Millisecond, 110 * time.Millisecond},
+		{2, 200 * time.Millisecond, 210 * time.Millisecond},
+		{3, 400 * time.Millisecond, 410 * time.Millisecond},
+		{5, time.Second, time.Second},
+		{100, time.Second, time.Second},
+	}
+
+	for _, test := range tests {
+		interval := backoff.Next(test.retry, 0)
+
+		assert.GreaterOrEqual(t, interval, test.min)
+		assert.LessOrEqual(t, interval, test.max)
+	}
+}
+
+func TestFullJitterBackoff_WhenRetrying_ThenIntervalBelowExponentialAndMax(t *testing.T) {
+	backoff := f3Client.FullJitterBackoff(100*time.Millisecond, 300*time.Millisecond)
+
+	for retry := 1; retry <= 10; retry++ {
+		interval := backoff.Next(retry, 0)
+
+		assert.GreaterOrEqual(t, interval, time.Duration(0))
+		assert.Less(t, interval, 300*time.Millisecond)
+		if retry == 1 {
+			assert.Less(t, interval, 100*time.Millisecond)
+		}
+	}
+}
+
+func TestDecorrelatedJitterBackoff_WhenRetrying_ThenIntervalBetweenBaseAndTriplePrevious(t *testing.T) {
+	var (
+		backoff  = f3Client.DecorrelatedJitterBackoff(100*time.Millisecond, 2*time.Second)
+		previous time.Duration
+	)
+
+	for retry := 1; retry <= 20; retry++ {
+		interval := backoff.Next(retry, previous)
+
+		assert.GreaterOrEqual(t, interval, 100*time.Millisecond)
+		assert.LessOrEqual(t, interval, 2*time.Second)
+		if previous > 0 {
+			assert.Less(t, interval, 3*previous)
+		}
+
+		previous = interval
+	}
+}
+
+func TestConstantBackoff_WhenRetrying_ThenAlwaysSameInterval(t *testing.T) {
+	backoff := f3Client.ConstantBackoff(250 * time.Millisecond)
+
+	assert.Equal(t, 250*time.Millisecond, backoff.Next(1, 0))
+	assert.Equal(t, 250*time.Millisecond, backoff.Next(10, time.Hour))
+}
+
+func TestCappedBackoff_WhenIntervalExceedsMax_ThenReturnsMax(t *testing.T) {
+	backoff := f3Client.CappedBackoff(f3Client.ConstantBackoff(time.Minute), 5*time.Second)
+
+	assert.Equal(t, 5*time.Second, backoff.Next(1, 0))
+}
+
+func TestRetriesWithPolicy_WhenRetryableStatus_ThenRetriesWithBackoff(t *testing.T) {
+	var (
+		attempts int
+		clock    = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts++; attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":"test-id"}}`))
+	}))
+	defer server.Close()
+
+	c := f3Client.NewClient(
+		f3Client.BaseURL(server.URL),
+		f3Client.RetriesWithPolicy(3, f3Client.ConstantBackoff(time.Second), f3Client.WithRetryClock(clock)),
+	)
+
+	account, err := c.Fetch(context.Background(), "test-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test-id", account.ID)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []time.Duration{time.Second, time.Second}, clock.waits)
+}
+
+func TestRetries_WhenIntervals_ThenWaitsNanosecondsWhileRetriesWithBackoffWaitsDurations(t *testing.T) {
+	tests := []struct {
+		name      string
+		option    func(clock f3Client.Clock) f3Client.ClientOption
+		expWaits  []time.Duration
+		maxJitter time.Duration
+	}{
+		{
+			name: "Retries",
+			option: func(clock f3Client.Clock) f3Client.ClientOption {
+				return f3Client.Retries(3, 2250, 150, f3Client.WithRetryClock(clock))
+			},
+			expWaits:  []time.Duration{2250, 4500},
+			maxJitter: 150,
+		},
+		{
+			name: "RetriesWithBackoff",
+			option: func(clock f3Client.Clock) f3Client.ClientOption {
+				return f3Client.RetriesWithBackoff(3, 2250*time.Millisecond, 150*time.Millisecond, f3Client.WithRetryClock(clock))
+			},
+			expWaits:  []time.Duration{2250 * time.Millisecond, 4500 * time.Millisecond},
+			maxJitter: 150 * time.Millisecond,
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+			c := f3Client.NewClient(f3Client.BaseURL(server.URL), tt.option(clock))
+
+			_, err := c.Fetch(context.Background(), "test-id")
+
+			assert.ErrorIs(t, err, f3Client.ErrServer)
+			assert.Len(t, clock.waits, len(tt.expWaits))
+			for i, wait := range clock.waits {
+				assert.GreaterOrEqual(t, wait, tt.expWaits[i])
+				assert.Less(t, wait, tt.expWaits[i]+tt.maxJitter)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package form3client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through while recording its outcome.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every request with ErrCircuitOpen until the cool-down ends.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited amount of probe requests through to decide
+	// whether to close or open again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type breakerConfig struct {
+	window        int
+	failureRate   float64
+	minRequests   int
+	coolDown      time.Duration
+	probes        int
+	isFailure     func(resp *http.Response, err error) bool
+	onStateChange func(from, to CircuitState)
+	clock         Clock
+}
+
+// BreakerOption is any function that can work as an option to set CircuitBreaker
+// features, following the same Functional Options approach as ClientOption.
+type BreakerOption func(breakerConfig) breakerConfig
+
+// BreakerWindow specifies the amount of most recent outcomes used to compute
+// the failure rate, by default 20.
+func BreakerWindow(size uint) BreakerOption {
+	return func(c breakerConfig) breakerConfig {
+		if size > 0 {
+			c.window = int(size)
+		}
+		return c
+	}
+}
+
+// BreakerFailureRate specifies the failure rate, between 0 and 1, that opens the circuit
+// once at least minRequests outcomes are in the window, by default 0.5 and 10.
+// A minRequests greater than the window size is reduced to the window size.
+func BreakerFailureRate(rate float64, minRequests uint) BreakerOption {
+	return func(c breakerConfig) breakerConfig {
+		c.failureRate = rate
+		c.minRequests = int(minRequests)
+		return c
+	}
+}
+
+// BreakerCoolDown specifies how long the circuit stays open before letting probe
+// requests through, by default 30 seconds.
+func BreakerCoolDown(coolDown time.Duration) BreakerOption {
+	return func(c breakerConfig) breakerConfig {
+		c.coolDown = coolDown
+		return c
+	}
+}
+
+// BreakerProbes specifies the amount of probe requests let through while half-open,
+// all of them must succeed to close the circuit, by default 1.
+func BreakerProbes(probes uint) BreakerOption {
+	return func(c breakerConfig) breakerConfig {
+		if probes > 0 {
+			c.probes = int(probes)
+		}
+		return c
+	}
+}
+
+// BreakerFailure specifies how the outcome of a request is classified, by default
+// transport errors and 429 or 5xx responses are failures.
+func BreakerFailure(isFailure func(resp *http.Response, err error) bool) BreakerOption {
+	return func(c breakerConfig) breakerConfig {
+		if isFailure != nil {
+			c.isFailure = isFailure
+		}
+		return c
+	}
+}
+
+// BreakerOnStateChange specifies a function called on every state change of the
+// circuit, i.e. to alert when it opens. It's called outside the breaker lock.
+func BreakerOnStateChange(onStateChange func(from, to CircuitState)) BreakerOption {
+	return func(c breakerConfig) breakerConfig {
+		c.onStateChange = onStateChange
+		return c
+	}
+}
+
+// BreakerClock specifies the Clock used to measure the cool-down, by default
+// the real time is used.
+func BreakerClock(clock Clock) BreakerOption {
+	return func(c breakerConfig) breakerConfig {
+		if clock != nil {
+			c.clock = clock
+		}
+		return c
+	}
+}
+
+// CircuitBreaker stops sending requests to an API that keeps failing, giving it
+// time to recover instead of multiplying its load with retries.
+//
+// Installed as a Middleware it returns ErrCircuitOpen without making the request
+// while open. It's safe for concurrent use and can be shared by several Clients.
+//
+// To use it, create an instance with NewCircuitBreaker, the zero value of this
+// CircuitBreaker is not safe to use.
+type CircuitBreaker struct {
+	config breakerConfig
+
+	mu         sync.Mutex
+	state      CircuitState
+	generation uint64
+	outcomes   []bool
+	next       int
+	failures   int
+	openedAt   time.Time
+	inFlight   int
+	successes  int
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker, default settings will be
+// applied if no options are injected.
+func NewCircuitBreaker(options ...BreakerOption) *CircuitBreaker {
+	config := breakerConfig{
+		window:      20,
+		failureRate: 0.5,
+		minRequests: 10,
+		coolDown:    30 * time.Second,
+		probes:      1,
+		isFailure:   isBreakerFailure,
+		clock:       realClock{},
+	}
+
+	for _, option := range options {
+		config = option(config)
+	}
+
+	// the window never holds more outcomes than its size, so a greater minRequests
+	// would never open the circuit.
+	if config.minRequests > config.window {
+		config.minRequests = config.window
+	}
+
+	return &CircuitBreaker{
+		config:   config,
+		outcomes: make([]bool, 0, config.window),
+	}
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitOpen && cb.coolDownElapsed() {
+		return CircuitHalfOpen
+	}
+
+	return cb.state
+}
+
+// Middleware returns the CircuitBreaker as a Middleware to be installed with WithMiddleware.
+func (cb *CircuitBreaker) Middleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(client http.Client, req *http.Request) (*http.Response, error) {
+			generation, err := cb.allow()
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next.Do(client, req)
+
+			// requests cancelled by the caller don't tell anything about the API health.
+			if req.Context().Err() != nil {
+				cb.release(generation)
+			} else {
+				cb.record(generation, cb.config.isFailure(resp, err))
+			}
+
+			return resp, err
+		})
+	}
+}
+
+func (cb *CircuitBreaker) allow() (uint64, error) {
+	var from, to CircuitState
+
+	cb.mu.Lock()
+
+	if cb.state == CircuitOpen && cb.coolDownElapsed() {
+		from, to = cb.setState(CircuitHalfOpen)
+	}
+
+	switch {
+	case cb.state == CircuitOpen:
+		cb.mu.Unlock()
+		return 0, ErrCircuitOpen
+	case cb.state == CircuitHalfOpen && cb.inFlight+cb.successes >= cb.config.probes:
+		cb.mu.Unlock()
+		cb.notify(from, to)
+		return 0, ErrCircuitOpen
+	case cb.state == CircuitHalfOpen:
+		cb.inFlight++
+	}
+
+	generation := cb.generation
+	cb.mu.Unlock()
+	cb.notify(from, to)
+
+	return generation, nil
+}
+
+func (cb *CircuitBreaker) record(generation uint64, failure bool) {
+	var from, to CircuitState
+
+	cb.mu.Lock()
+
+	if generation != cb.generation {
+		cb.mu.Unlock()
+		return
+	}
+
+	switch cb.state {
+	case CircuitClosed:
+		cb.push(failure)
+		if len(cb.outcomes) >= cb.config.minRequests &&
+			float64(cb.failures)/float64(len(cb.outcomes)) >= cb.config.failureRate {
+			from, to = cb.setState(CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		cb.inFlight--
+		if failure {
+			from, to = cb.setState(CircuitOpen)
+			break
+		}
+
+		if cb.successes++; cb.successes >= cb.config.probes {
+			from, to = cb.setState(CircuitClosed)
+		}
+	}
+
+	cb.mu.Unlock()
+	cb.notify(from, to)
+}
+
+// release frees the probe slot of a request whose outcome is not recorded.
+func (cb *CircuitBreaker) release(generation uint64) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if generation == cb.generation && cb.state == CircuitHalfOpen {
+		cb.inFlight--
+	}
+}
+
+// push adds the outcome to the window, replacing the oldest one when full.
+func (cb *CircuitBreaker) push(failure bool) {
+	if len(cb.outcomes) < cb.config.window {
+		cb.outcomes = append(cb.outcomes, failure)
+	} else {
+		if cb.outcomes[cb.next] {
+			cb.failures--
+		}
+		cb.outcomes[cb.next] = failure
+		cb.next = (cb.next + 1) % cb.config.window
+	}
+
+	if failure {
+		cb.failures++
+	}
+}
+
+// setState moves the circuit to a new state resetting its counters, outcomes of
+// requests allowed in a previous state are ignored thanks to the generation.
+func (cb *CircuitBreaker) setState(state CircuitState) (from, to CircuitState) {
+	from, to = cb.state, state
+
+	cb.state = state
+	cb.generation++
+	cb.outcomes = cb.outcomes[:0]
+	cb.next, cb.failures = 0, 0
+	cb.inFlight, cb.successes = 0, 0
+
+	if state == CircuitOpen {
+		cb.openedAt = cb.config.clock.Now()
+	}
+
+	return from, to
+}
+
+func (cb *CircuitBreaker) coolDownElapsed() bool {
+	return cb.config.clock.Now().Sub(cb.openedAt) >= cb.config.coolDown
+}
+
+func (cb *CircuitBreaker) notify(from, to CircuitState) {
+	if from != to && cb.config.onStateChange != nil {
+		cb.config.onStateChange(from, to)
+	}
+}
+
+func isBreakerFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,157 @@
+package form3client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	f3Client "form3-client-library"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker_WhenFailureRateReached_ThenOpensAndRejectsRequests(t *testing.T) {
+	var (
+		calls   int
+		changes []string
+		clock   = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		breaker = f3Client.NewCircuitBreaker(
+			f3Client.BreakerWindow(4),
+			f3Client.BreakerFailureRate(0.5, 4),
+			f3Client.BreakerClock(clock),
+			f3Client.BreakerOnStateChange(func(from, to f3Client.CircuitState) {
+				changes = append(changes, from.String()+"->"+to.String())
+			}),
+		)
+
+		statuses     = []int{http.StatusOK, http.StatusServiceUnavailable, http.StatusOK, http.StatusInternalServerError}
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			status := statuses[calls%len(statuses)]
+			calls++
+			return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc), f3Client.WithMiddleware(breaker.Middleware()))
+	)
+
+	for i := 0; i < len(statuses); i++ {
+		_, _ = c.Fetch(context.Background(), "test-id")
+	}
+
+	_, err := c.Fetch(context.Background(), "test-id")
+
+	assert.ErrorIs(t, err, f3Client.ErrCircuitOpen)
+	assert.Equal(t, len(statuses), calls)
+	assert.Equal(t, f3Client.CircuitOpen, breaker.State())
+	assert.Equal(t, []string{"closed->open"}, changes)
+}
+
+func TestCircuitBreaker_WhenWindowSmallerThanMinRequests_ThenOpensOnceWindowIsFull(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []f3Client.BreakerOption
+	}{
+		{"default min requests", []f3Client.BreakerOption{f3Client.BreakerWindow(5)}},
+		{"min requests greater than window", []f3Client.BreakerOption{f3Client.BreakerWindow(5), f3Client.BreakerFailureRate(0.5, 50)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				calls   int
+				breaker = f3Client.NewCircuitBreaker(tt.options...)
+
+				doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+					calls++
+					return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+				}
+
+				c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc), f3Client.WithMiddleware(breaker.Middleware()))
+			)
+
+			for i := 0; i < 10; i++ {
+				_, _ = c.Fetch(context.Background(), "test-id")
+			}
+
+			assert.Equal(t, 5, calls)
+			assert.Equal(t, f3Client.CircuitOpen, breaker.State())
+		})
+	}
+}
+
+func TestCircuitBreaker_WhenCoolDownElapsedAndProbesSucceed_ThenCloses(t *testing.T) {
+	var (
+		calls   int
+		changes []string
+		failing = true
+		clock   = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		breaker = f3Client.NewCircuitBreaker(
+			f3Client.BreakerFailureRate(1, 2),
+			f3Client.BreakerCoolDown(10*time.Second),
+			f3Client.BreakerProbes(2),
+			f3Client.BreakerClock(clock),
+			f3Client.BreakerOnStateChange(func(from, to f3Client.CircuitState) {
+				changes = append(changes, from.String()+"->"+to.String())
+			}),
+		)
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			calls++
+			if failing {
+				return &http.Response{StatusCode: http.StatusBadGateway, Body: http.NoBody}, nil
+			}
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc), f3Client.WithMiddleware(breaker.Middleware()))
+	)
+
+	_ = c.Delete(context.Background(), "test-id")
+	_ = c.Delete(context.Background(), "test-id")
+
+	assert.ErrorIs(t, c.Delete(context.Background(), "test-id"), f3Client.ErrCircuitOpen)
+
+	clock.now = clock.now.Add(10 * time.Second)
+	failing = false
+
+	assert.Equal(t, f3Client.CircuitHalfOpen, breaker.State())
+	assert.NoError(t, c.Delete(context.Background(), "test-id"))
+	assert.NoError(t, c.Delete(context.Background(), "test-id"))
+	assert.NoError(t, c.Delete(context.Background(), "test-id"))
+	assert.Equal(t, f3Client.CircuitClosed, breaker.State())
+	assert.Equal(t, 5, calls)
+	assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->closed"}, changes)
+}
+
+func TestCircuitBreaker_WhenProbeFails_ThenOpensAgain(t *testing.T) {
+	var (
+		calls int
+		clock = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		breaker = f3Client.NewCircuitBreaker(
+			f3Client.BreakerFailureRate(1, 1),
+			f3Client.BreakerCoolDown(time.Minute),
+			f3Client.BreakerClock(clock),
+		)
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			calls++
+			return nil, context.DeadlineExceeded
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc), f3Client.WithMiddleware(breaker.Middleware()))
+	)
+
+	_, _ = c.Fetch(context.Background(), "test-id")
+	clock.now = clock.now.Add(time.Minute)
+	_, _ = c.Fetch(context.Background(), "test-id")
+
+	_, err := c.Fetch(context.Background(), "test-id")
+
+	assert.ErrorIs(t, err, f3Client.ErrCircuitOpen)
+	assert.Equal(t, f3Client.CircuitOpen, breaker.State())
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,225 @@
+package form3client
+
+import "github.com/google/uuid"
+
+const (
+	accountsType = "accounts"
+)
+
+// AccountBuilder builds an AccountRequest step by step, allocating its optional
+// pointer attributes and generating its identifier.
+//
+// Every method returns a new AccountBuilder, so a partially built one can be reused
+// as a template, i.e. with the country preset and bank already set.
+//
+// To use it, create an instance with NewAccount.
+type AccountBuilder struct {
+	id             string
+	organisationID string
+	attributes     AccountAttributesRequest
+}
+
+// NewAccount returns an AccountBuilder of an account owned by the organisation orgID.
+func NewAccount(orgID string) AccountBuilder {
+	return AccountBuilder{organisationID: orgID}
+}
+
+// ID specifies the account identifier, by default a random UUID is generated on Build.
+func (b AccountBuilder) ID(id string) AccountBuilder {
+	b.id = id
+	return b
+}
+
+// Country presets the country, its bank ID code and base currency when known,
+// leaving the bank ID, BIC and account number to be set.
+func (b AccountBuilder) Country(country Country) AccountBuilder {
+	b.attributes.Country = country
+	b.attributes.BankIDCode = countryRules[country].bankIDCode
+	b.attributes.BaseCurrency = countryCurrencies[country]
+	return b
+}
+
+// UK presets a GB account with the GBDSC bank ID code and GBP currency.
+func (b AccountBuilder) UK() AccountBuilder {
+	return b.Country(CountryUnitedKingdom)
+}
+
+// DE presets a DE account with the DEBLZ bank ID code and EUR currency.
+func (b AccountBuilder) DE() AccountBuilder {
+	return b.Country(CountryGermany)
+}
+
+// FR presets a FR account with the FR bank ID code and EUR currency.
+func (b AccountBuilder) FR() AccountBuilder {
+	return b.Country(CountryFrance)
+}
+
+// US presets a US account with the USABA bank ID code and USD currency.
+func (b AccountBuilder) US() AccountBuilder {
+	return b.Country(CountryUnitedStates)
+}
+
+// BankID specifies the national bank identifier, i.e. the sort code of a GB account.
+func (b AccountBuilder) BankID(bankID string) AccountBuilder {
+	b.attributes.BankID = bankID
+	return b
+}
+
+// BankIDCode specifies the type of the bank ID, overriding the country preset.
+func (b AccountBuilder) BankIDCode(bankIDCode string) AccountBuilder {
+	b.attributes.BankIDCode = bankIDCode
+	return b
+}
+
+// Bic specifies the SWIFT BIC of the bank.
+func (b AccountBuilder) Bic(bic string) AccountBuilder {
+	b.attributes.Bic = bic
+	return b
+}
+
+// AccountNumber specifies the national account number.
+func (b AccountBuilder) AccountNumber(accountNumber string) AccountBuilder {
+	b.attributes.AccountNumber = accountNumber
+	return b
+}
+
+// Iban specifies the IBAN of the account.
+func (b AccountBuilder) Iban(iban string) AccountBuilder {
+	b.attributes.Iban = iban
+	return b
+}
+
+// BaseCurrency specifies the currency of the account, overriding the country preset.
+func (b AccountBuilder) BaseCurrency(currency Currency) AccountBuilder {
+	b.attributes.BaseCurrency = currency
+	return b
+}
+
+// Names specifies the names of the account holder, up to four lines.
+func (b AccountBuilder) Names(names ...string) AccountBuilder {
+	b.attributes.Name = append([]string(nil), names...)
+	return b
+}
+
+// AlternativeNames specifies the alternative names of the account holder, up to three lines.
+func (b AccountBuilder) AlternativeNames(names ...string) AccountBuilder {
+	b.attributes.AlternativeNames = append([]string(nil), names...)
+	return b
+}
+
+// SecondaryIdentification specifies the additional identification of the account,
+// i.e. a building society roll number.
+func (b AccountBuilder) SecondaryIdentification(identification string) AccountBuilder {
+	b.attributes.SecondaryIdentification = identification
+	return b
+}
+
+// Classification specifies whether the account is personal or business.
+func (b AccountBuilder) Classification(classification AccountClassification) AccountBuilder {
+	b.attributes.AccountClassification = &classification
+	return b
+}
+
+// Status specifies the status of the account.
+func (b AccountBuilder) Status(status AccountStatus) AccountBuilder {
+	b.attributes.Status = &status
+	return b
+}
+
+// JointAccount specifies whether the account is held by more than one holder.
+func (b AccountBuilder) JointAccount(joint bool) AccountBuilder {
+	b.attributes.JointAccount = &joint
+	return b
+}
+
+// AccountMatchingOptOut specifies whether the holder opted out of account name matching.
+func (b AccountBuilder) AccountMatchingOptOut(optOut bool) AccountBuilder {
+	b.attributes.AccountMatchingOptOut = &optOut
+	return b
+}
+
+// Switched specifies whether the account was switched to another bank.
+func (b AccountBuilder) Switched(switched bool) AccountBuilder {
+	b.attributes.Switched = &switched
+	return b
+}
+
+// UserDefinedData appends a key value pair of custom data to the account.
+func (b AccountBuilder) UserDefinedData(key, value string) AccountBuilder {
+	data := b.attributes.UserDefinedData
+	b.attributes.UserDefinedData = append(data[:len(data):len(data)], UserDefinedData{Key: key, Value: value})
+	return b
+}
+
+// Build returns the AccountRequest, generating its ID when not provided, once
+// it passes ValidateAccountRequest, otherwise its FieldErrors are returned.
+func (b AccountBuilder) Build() (AccountRequest, error) {
+	if b.id == "" {
+		b.id = uuid.NewString()
+	}
+
+	attributes := cloneAttributes(b.attributes)
+	account := AccountRequest{
+		ID:             b.id,
+		OrganisationID: b.organisationID,
+		Type:           accountsType,
+		Attributes:     &attributes,
+	}
+
+	if err := ValidateAccountRequest(account); err != nil {
+		return AccountRequest{}, err
+	}
+
+	return account, nil
+}
+
+// cloneAttributes returns a deep copy of the attributes, so the requests built from the
+// same AccountBuilder don't share their slices and pointers.
+func cloneAttributes(a AccountAttributesRequest) AccountAttributesRequest {
+	a.Name = cloneSlice(a.Name)
+	a.AlternativeNames = cloneSlice(a.AlternativeNames)
+	a.UserDefinedData = cloneSlice(a.UserDefinedData)
+	a.AccountClassification = clonePointer(a.AccountClassification)
+	a.Status = clonePointer(a.Status)
+	a.JointAccount = clonePointer(a.JointAccount)
+	a.AccountMatchingOptOut = clonePointer(a.AccountMatchingOptOut)
+	a.Switched = clonePointer(a.Switched)
+
+	return a
+}
+
+func cloneSlice[T any](values []T) []T {
+	if values == nil {
+		return nil
+	}
+
+	return append(make([]T, 0, len(values)), values...)
+}
+
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+
+	clone := *value
+	return &clone
+}
+
+var countryCurrencies = map[Country]Currency{
+	CountryAustralia:     CurrencyAUD,
+	CountryBelgium:       CurrencyEUR,
+	CountryCanada:        CurrencyCAD,
+	CountryFrance:        CurrencyEUR,
+	CountryGermany:       CurrencyEUR,
+	CountryGreece:        CurrencyEUR,
+	CountryHongKong:      CurrencyHKD,
+	CountryItaly:         CurrencyEUR,
+	CountryLuxembourg:    CurrencyEUR,
+	CountryNetherlands:   CurrencyEUR,
+	CountryPoland:        CurrencyPLN,
+	CountryPortugal:      CurrencyEUR,
+	CountrySpain:         CurrencyEUR,
+	CountrySwitzerland:   CurrencyCHF,
+	CountryUnitedKingdom: CurrencyGBP,
+	CountryUnitedStates:  CurrencyUSD,
+}
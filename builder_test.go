@@ -0,0 +1,100 @@
+package form3client_test
+
+import (
+	"testing"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountBuilder_WhenUKAccount_ThenBuildsRequest(t *testing.T) {
+	orgID := uuid.NewString()
+
+	account, err := f3Client.NewAccount(orgID).
+		UK().
+		BankID("400300").
+		Bic("NWBKGB22").
+		AccountNumber("41426819").
+		Names("Samantha Holder").
+		Classification(f3Client.ClassificationPersonal).
+		JointAccount(false).
+		UserDefinedData("key", "value").
+		Build()
+
+	assert.NoError(t, err)
+	_, err = uuid.Parse(account.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, orgID, account.OrganisationID)
+	assert.Equal(t, "accounts", account.Type)
+
+	classification, joint := f3Client.ClassificationPersonal, false
+	assert.Equal(t, &f3Client.AccountAttributesRequest{
+		AccountClassification: &classification,
+		AccountNumber:         "41426819",
+		BankID:                "400300",
+		BankIDCode:            "GBDSC",
+		BaseCurrency:          f3Client.CurrencyGBP,
+		Bic:                   "NWBKGB22",
+		Country:               f3Client.CountryUnitedKingdom,
+		JointAccount:          &joint,
+		Name:                  []string{"Samantha Holder"},
+		UserDefinedData:       []f3Client.UserDefinedData{{Key: "key", Value: "value"}},
+	}, account.Attributes)
+}
+
+func TestAccountBuilder_WhenTemplateReused_ThenBuildsIndependentRequests(t *testing.T) {
+	template := f3Client.NewAccount(uuid.NewString()).DE().BankID("37040044").UserDefinedData("team", "payments")
+
+	first, err := template.Names("First Holder").UserDefinedData("n", "1").Build()
+	assert.NoError(t, err)
+
+	second, err := template.ID("fixed-id").Names("Second Holder").Build()
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.Equal(t, "fixed-id", second.ID)
+	assert.Equal(t, []string{"First Holder"}, first.Attributes.Name)
+	assert.Equal(t, []string{"Second Holder"}, second.Attributes.Name)
+	assert.Len(t, first.Attributes.UserDefinedData, 2)
+	assert.Len(t, second.Attributes.UserDefinedData, 1)
+}
+
+func TestAccountBuilder_WhenBuiltRequestModified_ThenOtherBuildsUnchanged(t *testing.T) {
+	builder := f3Client.NewAccount(uuid.NewString()).UK().BankID("400300").Bic("NWBKGB22").
+		Names("Samantha Holder").AlternativeNames("Sam Holder").UserDefinedData("team", "payments").
+		Status(f3Client.AccountStatusConfirmed).JointAccount(false)
+
+	first, err := builder.Build()
+	assert.NoError(t, err)
+
+	second, err := builder.Build()
+	assert.NoError(t, err)
+
+	first.Attributes.Name[0] = "Changed"
+	first.Attributes.AlternativeNames[0] = "Changed"
+	first.Attributes.UserDefinedData[0].Value = "changed"
+	*first.Attributes.Status = f3Client.AccountStatusClosed
+	*first.Attributes.JointAccount = true
+
+	third, err := builder.Build()
+	assert.NoError(t, err)
+
+	for _, account := range []f3Client.AccountRequest{second, third} {
+		assert.Equal(t, []string{"Samantha Holder"}, account.Attributes.Name)
+		assert.Equal(t, []string{"Sam Holder"}, account.Attributes.AlternativeNames)
+		assert.Equal(t, []f3Client.UserDefinedData{{Key: "team", Value: "payments"}}, account.Attributes.UserDefinedData)
+		assert.Equal(t, f3Client.AccountStatusConfirmed, *account.Attributes.Status)
+		assert.False(t, *account.Attributes.JointAccount)
+	}
+}
+
+func TestAccountBuilder_WhenInvalid_ThenBuildReturnsFieldErrors(t *testing.T) {
+	account, err := f3Client.NewAccount("").UK().BankID("4003").Build()
+
+	assert.ErrorIs(t, err, f3Client.ErrInvalidAccount)
+	assert.EqualError(t, err, "organisation_id is required; attributes.name is required; "+
+		`attributes.bank_id "4003" doesn't match ^[0-9]{6}$ for country GB; attributes.bic is required for country GB`)
+	assert.Equal(t, f3Client.AccountRequest{}, account)
+}
@@ -0,0 +1,71 @@
+package form3client
+
+import (
+	"net/http"
+	"time"
+)
+
+// CallOption is any function that can work as an option to override the Client
+// features for a single call, following the same Functional Options approach as
+// ClientOption. The Client itself is never modified.
+type CallOption func(Client) Client
+
+// WithTimeout overrides the Client Timeout for a single call, see Timeout.
+func WithTimeout(timeout time.Duration) CallOption {
+	return func(c Client) Client {
+		c.client.Timeout = timeout
+		return c
+	}
+}
+
+// WithRetries overrides the retries made by the Client for a single call, as RetriesWithPolicy
+// does, keeping the Doer that sends the request, i.e. the one set with MockDoer.
+func WithRetries(retryAttempts uint, backoff Backoff, options ...RetryOption) CallOption {
+	return func(c Client) Client {
+		next := c.doer
+		if retry, ok := next.(retryDoer); ok {
+			next = retry.next
+		}
+
+		retry := newRetryDoer(retryAttempts, append([]RetryOption{WithBackoff(backoff)}, options...)...)
+		retry.next = next
+		c.doer = retry
+
+		return c
+	}
+}
+
+// WithHeader adds a header to the request of a single call, headers set by the Client
+// like Content-Type can't be overridden.
+func WithHeader(key, value string) CallOption {
+	return func(c Client) Client {
+		headers := c.headers.Clone()
+		if headers == nil {
+			headers = http.Header{}
+		}
+
+		headers.Add(key, value)
+		c.headers = headers
+
+		return c
+	}
+}
+
+// WithIdempotencyKey specifies the Idempotency-Key of a single mutating call, overriding
+// the one provided with ContextWithIdempotencyKey or generated by IdempotencyKeys.
+func WithIdempotencyKey(key string) CallOption {
+	return func(c Client) Client {
+		c.callIdempotencyKey = key
+		return c
+	}
+}
+
+// with returns a copy of the Client with the call options applied.
+func (c *Client) with(options []CallOption) *Client {
+	call := *c
+	for _, option := range options {
+		call = option(call)
+	}
+
+	return &call
+}
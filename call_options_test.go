@@ -0,0 +1,88 @@
+package form3client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallOptions_WhenHeaderAndIdempotencyKey_ThenOnlyThisCallSendsThem(t *testing.T) {
+	var (
+		headers []http.Header
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			headers = append(headers, req.Header)
+			return &http.Response{StatusCode: http.StatusCreated, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := c.Create(context.Background(), newGBAccountRequest(),
+		f3Client.WithHeader("X-Trace-Id", "trace-1"),
+		f3Client.WithHeader("Content-Type", "text/plain"),
+		f3Client.WithIdempotencyKey("key-1"),
+	)
+	assert.NoError(t, err)
+
+	_, err = c.Create(context.Background(), newGBAccountRequest())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "trace-1", headers[0].Get("X-Trace-Id"))
+	assert.Equal(t, "application/json", headers[0].Get("Content-Type"))
+	assert.Equal(t, "key-1", headers[0].Get("Idempotency-Key"))
+	assert.Empty(t, headers[1].Get("X-Trace-Id"))
+	assert.Empty(t, headers[1].Get("Idempotency-Key"))
+}
+
+func TestCallOptions_WhenTimeoutAndRetries_ThenOverrideClientForThisCall(t *testing.T) {
+	var (
+		timeouts []time.Duration
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			timeouts = append(timeouts, client.Timeout)
+			if len(timeouts) == 1 {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+			}
+
+			return &http.Response{StatusCode: http.StatusOK, Body: getReaderFromInterface(f3Client.AccountResponse{})}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := c.Fetch(context.Background(), uuid.NewString(),
+		f3Client.WithTimeout(10*time.Second),
+		f3Client.WithRetries(2, nil),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{10 * time.Second, 10 * time.Second}, timeouts)
+
+	timeouts = nil
+
+	_, err = c.Fetch(context.Background(), uuid.NewString())
+	assert.ErrorIs(t, err, f3Client.ErrServer)
+	assert.Equal(t, []time.Duration{2 * time.Second}, timeouts)
+}
+
+func TestCallOptions_WhenDelete_ThenAppliedToDeleteVersion(t *testing.T) {
+	var (
+		key string
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			key = req.Header.Get("Idempotency-Key")
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	assert.NoError(t, c.Delete(context.Background(), uuid.NewString(), f3Client.WithIdempotencyKey("key-1")))
+	assert.Equal(t, "key-1", key)
+}
@@ -3,11 +3,14 @@ package form3client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -34,9 +37,21 @@ type Doer interface {
 // To use it, create an instance with NewClient, the zero value of this Client
 // is not safe to use.
 type Client struct {
-	client  http.Client
-	doer    Doer
-	baseURL string
+	client          http.Client
+	doer            Doer
+	middlewares     []Middleware
+	baseURL         string
+	strictEnums     bool
+	validate        bool
+	idempotencyKeys bool
+	tlsConfig       *tls.Config
+
+	// err is the failure of a ClientOption, returned by every call.
+	err error
+
+	// per-call settings, see CallOption.
+	headers            http.Header
+	callIdempotencyKey string
 }
 
 // NewClient is the only way to properly instantiate a Form3 Client.
@@ -78,7 +93,14 @@ func NewClient(options ...ClientOption) Client {
 //
 // Errors related to the request or resource trying to be obtained will be of type
 // RequestError, while server side errors will be of type error.
-func (c *Client) Fetch(ctx context.Context, id string) (Account, error) {
+//
+// Options: type CallOption allows to override the Client timeout, retries, headers and
+// idempotency key for this call only.
+func (c *Client) Fetch(ctx context.Context, id string, options ...CallOption) (Account, error) {
+	if len(options) > 0 {
+		return c.with(options).Fetch(ctx, id)
+	}
+
 	if containsOnlyBlanks(id) {
 		return Account{}, ErrRequiredID
 	}
@@ -88,18 +110,18 @@ func (c *Client) Fetch(ctx context.Context, id string) (Account, error) {
 		return Account{}, err
 	}
 
-	resp, err := c.doer.Do(c.client, req)
+	resp, err := c.do(req)
 	if err != nil {
 		return Account{}, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return Account{}, handleResponseError(resp)
+		return Account{}, handleResponseError(req, resp)
 	}
 
 	var rData AccountResponse
-	if err := unmarshalBody(resp.Body, &rData); err != nil {
+	if err := c.decode(resp.Body, &rData); err != nil {
 		return Account{}, err
 	}
 
@@ -112,32 +134,88 @@ func (c *Client) Fetch(ctx context.Context, id string) (Account, error) {
 //
 // id  (string) identifier of the Form3 account to delete.
 //
+// Delete removes the version 0 of the account, to remove a modified account
+// use DeleteVersion or DeleteCurrent.
+//
 // If no id is provided RequestErr with the ErrRequiredID is returned and it can't
 // contain only blanks.
 //
 // Errors related to the request or resource trying to be deleted will be of type
 // RequestError, while server side errors will be of type error.
-func (c *Client) Delete(ctx context.Context, id string) error {
+//
+// Options: type CallOption allows to override the Client timeout, retries, headers and
+// idempotency key for this call only.
+func (c *Client) Delete(ctx context.Context, id string, options ...CallOption) error {
+	return c.DeleteVersion(ctx, id, 0, options...)
+}
+
+// DeleteVersion allows to remove a specific version of an account by its identifier providing:
+//
+// ctx (context.Context) context carries a deadline, a cancellation signal, and other values across API boundaries.
+//
+// id  (string) identifier of the Form3 account to delete.
+//
+// version  (int64) current version of the Form3 account to delete.
+//
+// If no id is provided RequestErr with the ErrRequiredID is returned and it can't
+// contain only blanks.
+//
+// If the provided version is not the current version of the account a RequestError matching
+// ErrVersionConflict with errors.Is is returned.
+//
+// Errors related to the request or resource trying to be deleted will be of type
+// RequestError, while server side errors will be of type error.
+//
+// Options: type CallOption allows to override the Client timeout, retries, headers and
+// idempotency key for this call only.
+func (c *Client) DeleteVersion(ctx context.Context, id string, version int64, options ...CallOption) error {
+	if len(options) > 0 {
+		return c.with(options).DeleteVersion(ctx, id, version)
+	}
+
 	if containsOnlyBlanks(id) {
 		return ErrRequiredID
 	}
 
-	req, err := c.makeJSONRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s?version=0", accountsPath, id), nil)
+	req, err := c.makeJSONRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s?version=%d", accountsPath, id, version), nil)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.doer.Do(c.client, req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent {
-		return handleResponseError(resp)
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusConflict:
+		return handleVersionConflictError(req, resp)
+	default:
+		return handleResponseError(req, resp)
 	}
+}
 
-	return nil
+// DeleteCurrent allows to remove the current version of an account by its identifier providing:
+//
+// ctx (context.Context) context carries a deadline, a cancellation signal, and other values across API boundaries.
+//
+// id  (string) identifier of the Form3 account to delete.
+//
+// The account is fetched first to obtain its current version, so ErrVersionConflict
+// is returned if the account is modified between both requests.
+//
+// Errors related to the request or resource trying to be deleted will be of type
+// RequestError, while server side errors will be of type error.
+func (c *Client) DeleteCurrent(ctx context.Context, id string) error {
+	account, err := c.Fetch(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return c.DeleteVersion(ctx, id, int64(account.Version))
 }
 
 // Create allows to register a new account resource:
@@ -148,32 +226,242 @@ func (c *Client) Delete(ctx context.Context, id string) error {
 //
 // If the resource is successfully created the func will return an (Account) object with the base information.
 //
+// When the ClientOption ValidateRequests is set the account is checked with ValidateAccountRequest
+// and its FieldErrors are returned without making any request.
+//
+// Options: type CallOption allows to override the Client timeout, retries, headers and
+// idempotency key for this call only.
+//
 // Errors related to the request  will be of type
 // RequestError, while server side errors will be of type error.
-func (c *Client) Create(ctx context.Context, account AccountRequest) (Account, error) {
+func (c *Client) Create(ctx context.Context, account AccountRequest, options ...CallOption) (Account, error) {
+	if len(options) > 0 {
+		return c.with(options).Create(ctx, account)
+	}
+
+	if c.validate {
+		if err := ValidateAccountRequest(account); err != nil {
+			return Account{}, err
+		}
+	}
+
 	req, err := c.makeJSONRequest(ctx, http.MethodPost, accountsPath, CreateAccountRequest{account})
 	if err != nil {
 		return Account{}, err
 	}
 
-	resp, err := c.doer.Do(c.client, req)
+	resp, err := c.do(req)
 	if err != nil {
 		return Account{}, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return Account{}, handleResponseError(resp)
+		return Account{}, handleResponseError(req, resp)
+	}
+
+	var rData AccountResponse
+	if err := c.decode(resp.Body, &rData); err != nil {
+		return Account{}, err
+	}
+
+	return rData.Account, nil
+}
+
+// List allows to retrieve a page of account resources providing:
+//
+// ctx (context.Context) context carries a deadline, a cancellation signal, and other values across API boundaries.
+//
+// options  (ListOptions) page number, page size and filter criteria of the accounts to list.
+//
+// The returned (AccountPage) contains the accounts of the requested page and the
+// first, prev, next and last links provided by the API.
+//
+// Errors related to the request will be of type
+// RequestError, while server side errors will be of type error.
+func (c *Client) List(ctx context.Context, options ListOptions) (AccountPage, error) {
+	path := accountsPath
+	if query := options.query().Encode(); query != "" {
+		path = fmt.Sprintf("%s?%s", accountsPath, query)
+	}
+
+	page, err := c.listPage(ctx, path)
+	if err != nil {
+		return AccountPage{}, err
+	}
+
+	page.PageNumber = options.PageNumber
+	page.PageSize = options.PageSize
+
+	return page, nil
+}
+
+func (c *Client) listPage(ctx context.Context, path string) (AccountPage, error) {
+	req, err := c.makeJSONRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return AccountPage{}, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return AccountPage{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return AccountPage{}, handleResponseError(req, resp)
+	}
+
+	var rData AccountListResponse
+	if err := c.decode(resp.Body, &rData); err != nil {
+		return AccountPage{}, err
+	}
+
+	return AccountPage{Accounts: rData.Accounts, Links: rData.Links}, nil
+}
+
+func (o ListOptions) query() url.Values {
+	query := url.Values{}
+
+	if o.PageNumber != 0 || o.PageSize != 0 {
+		query.Set("page[number]", strconv.FormatUint(uint64(o.PageNumber), 10))
+	}
+
+	if o.PageSize != 0 {
+		query.Set("page[size]", strconv.FormatUint(uint64(o.PageSize), 10))
+	}
+
+	filters := map[string]string{
+		"bank_id":        o.Filter.BankID,
+		"bank_id_code":   o.Filter.BankIDCode,
+		"account_number": o.Filter.AccountNumber,
+		"iban":           o.Filter.Iban,
+		"country":        string(o.Filter.Country),
+		"customer_id":    o.Filter.CustomerID,
+	}
+
+	for name, value := range filters {
+		if !containsOnlyBlanks(value) {
+			query.Set(fmt.Sprintf("filter[%s]", name), value)
+		}
+	}
+
+	return query
+}
+
+// Update allows to modify an account resource by its identifier providing:
+//
+// ctx (context.Context) context carries a deadline, a cancellation signal, and other values across API boundaries.
+//
+// id  (string) identifier of the Form3 account to update.
+//
+// patch  (AccountRequest) patch contains the attributes to modify and the current Version of the account,
+// its empty fields are not sent so they are left unchanged.
+//
+// If no id is provided RequestErr with the ErrRequiredID is returned and it can't
+// contain only blanks, while a patch without Version returns ErrRequiredVersion.
+//
+// If the account was modified since the provided version a RequestError matching
+// ErrVersionConflict with errors.Is is returned.
+//
+// Errors related to the request or resource trying to be updated will be of type
+// RequestError, while server side errors will be of type error.
+func (c *Client) Update(ctx context.Context, id string, patch AccountRequest) (Account, error) {
+	if containsOnlyBlanks(id) {
+		return Account{}, ErrRequiredID
+	}
+
+	if patch.Version == nil {
+		return Account{}, ErrRequiredVersion
+	}
+
+	if containsOnlyBlanks(patch.ID) {
+		patch.ID = id
+	}
+
+	req, err := c.makeJSONRequest(ctx, http.MethodPatch, fmt.Sprintf("%s/%s", accountsPath, id), UpdateAccountRequest{newAccountPatch(patch)})
+	if err != nil {
+		return Account{}, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return Account{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusConflict:
+		return Account{}, handleVersionConflictError(req, resp)
+	default:
+		return Account{}, handleResponseError(req, resp)
 	}
 
 	var rData AccountResponse
-	if err := unmarshalBody(resp.Body, &rData); err != nil {
+	if err := c.decode(resp.Body, &rData); err != nil {
 		return Account{}, err
 	}
 
 	return rData.Account, nil
 }
 
+// Modify allows to read, modify and write an account resource by its identifier providing:
+//
+// ctx (context.Context) context carries a deadline, a cancellation signal, and other values across API boundaries.
+//
+// id  (string) identifier of the Form3 account to modify.
+//
+// maxAttempts (uint) maximum amount of read-modify-write cycles, a zero value is considered a single attempt.
+//
+// modify (func(Account) (AccountRequest, error)) builds the patch from the current account, its
+// ID, OrganisationID, Type and Version are always overridden with the ones of the fetched account.
+//
+// Whenever the update fails with ErrVersionConflict the account is fetched again and modify
+// is called with its latest state, until maxAttempts is reached and ErrVersionConflict is returned.
+//
+// The Idempotency-Key of a ctx made with ContextWithIdempotencyKey is suffixed by the attempt
+// number from the second attempt on, as every attempt sends a different patch.
+func (c *Client) Modify(ctx context.Context, id string, maxAttempts uint, modify func(account Account) (AccountRequest, error)) (Account, error) {
+	for attempt := uint(1); ; attempt++ {
+		account, err := c.Fetch(ctx, id)
+		if err != nil {
+			return Account{}, err
+		}
+
+		patch, err := modify(account)
+		if err != nil {
+			return Account{}, err
+		}
+
+		version := int64(account.Version)
+		patch.ID, patch.OrganisationID, patch.Type = account.ID, account.OrganisationID, account.Type
+		patch.Version = &version
+
+		account, err = c.Update(withAttemptIdempotencyKey(ctx, attempt), id, patch)
+		if !errors.Is(err, ErrVersionConflict) || attempt >= maxAttempts {
+			return account, err
+		}
+	}
+}
+
+// do sends the request through the installed middlewares and the client Doer, errors
+// of requests that reached their deadline are returned as ErrTimeout and keep the
+// Idempotency-Key of the request.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := chain(c.doer, c.middlewares).Do(c.client, req)
+	if err != nil {
+		err = mapTransportError(err)
+		if key := req.Header.Get(idempotencyKeyHeader); key != "" {
+			err = idempotencyKeyError{key: key, err: err}
+		}
+
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (c *Client) resolveURL(path string) (*url.URL, error) {
 	return url.Parse(c.baseURL + path)
 }
@@ -183,12 +471,27 @@ func containsOnlyBlanks(value string) bool {
 }
 
 func (c *Client) makeJSONRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
-	payload, err := json.Marshal(body)
-	if err != nil {
-		return nil, ErrSerializeRequest
+	if c.err != nil {
+		return nil, c.err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, path, bytes.NewReader(payload))
+	var payload io.Reader
+	if body != nil {
+		if validator, ok := body.(enumValidator); ok && c.strictEnums {
+			if err := validator.ValidateEnums(); err != nil {
+				return nil, err
+			}
+		}
+
+		content, err := json.Marshal(body)
+		if err != nil {
+			return nil, ErrSerializeRequest
+		}
+
+		payload = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -198,13 +501,36 @@ func (c *Client) makeJSONRequest(ctx context.Context, method, path string, body
 		return nil, err
 	}
 
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
 	if body != nil {
-		req.Header.Add(contentTypeHeader, jsonContentType)
+		req.Header.Set(contentTypeHeader, jsonContentType)
+	}
+
+	if key := c.idempotencyKey(ctx, method); key != "" {
+		req.Header.Set(idempotencyKeyHeader, key)
 	}
 
 	return req, nil
 }
 
+// decode unmarshals the response body, rejecting unknown enum values when StrictEnums is set.
+func (c *Client) decode(body io.Reader, value any) error {
+	if err := unmarshalBody(body, value); err != nil {
+		return err
+	}
+
+	if validator, ok := value.(enumValidator); ok && c.strictEnums {
+		return validator.ValidateEnums()
+	}
+
+	return nil
+}
+
 func unmarshalBody(body io.Reader, value any) error {
 	content, err := io.ReadAll(body)
 	if err != nil {
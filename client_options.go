@@ -1,8 +1,13 @@
 package form3client
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"form3-client-library/mocks"
 	"net/http"
+	"os"
 	"time"
 )
 
@@ -44,20 +49,219 @@ func Timeout(timeout time.Duration) ClientOption {
 //
 // RetryAttempts: type uint specifys the amount of retries attempts
 //
-// BackoffInterval: type uint specifys the behind backoff interval in milliseconds
+// BackoffInterval: type uint specifys the behind backoff interval
 // and is to use progressively exponential longer waits between retries for consecutive error responses
 //
-// MaximumJitterInterval: type uint specifys the maximum jitter interval (randomized delay) in milliseconds to prevent successive collisions
+// MaximumJitterInterval: type uint specifys the maximum jitter interval (randomized delay) to prevent successive collisions
 // use in the exponential backoff interval algorithm
 //
+// Both intervals are nanoseconds, see NewRetryDoer, use RetriesWithBackoff to specify
+// them as time.Duration.
+//
+// Options: type RetryOption allows to change the RetryPolicy and observe its decisions, see NewRetryDoer
+//
 // Retries is consider default if any of the params is set to its zero/empty value, so it will not retry
-func Retries(retryAttempts, backoffIntvl, maxJitterIntvl uint) ClientOption {
+func Retries(retryAttempts, backoffIntvl, maxJitterIntvl uint, options ...RetryOption) ClientOption {
+	return func(c Client) Client {
+		c.doer = NewRetryDoer(retryAttempts, backoffIntvl, maxJitterIntvl, options...)
+		return c
+	}
+}
+
+// RetriesWithBackoff specifies the number of request attempts made by the Client with the
+// exponential backoff of Retries, taking its intervals as time.Duration.
+//
+// RetryAttempts: type uint specifies the amount of retries attempts, zero means no retries
+//
+// BackoffInterval: type time.Duration specifies the wait before the first retry, doubled
+// on every following retry
+//
+// MaximumJitterInterval: type time.Duration specifies the maximum random delay added to
+// every wait, zero means no jitter
+//
+// Options: type RetryOption allows to change the RetryPolicy and observe its decisions, see NewRetryDoer
+func RetriesWithBackoff(retryAttempts uint, backoffIntvl, maxJitterIntvl time.Duration, options ...RetryOption) ClientOption {
+	return RetriesWithPolicy(retryAttempts, ExponentialBackoff(backoffIntvl, maxJitterIntvl, 0), options...)
+}
+
+// RetriesWithPolicy specifies the number of request attempts made by the Client
+// using a custom Backoff strategy, as an alternative to the exponential backoff of Retries.
+//
+// RetryAttempts: type uint specifies the amount of retries attempts, zero means no retries
+//
+// Backoff: type Backoff computes the waits between attempts, i.e. a capped
+// DecorrelatedJitterBackoff. A nil Backoff retries without waiting
+//
+// Options: type RetryOption allows to change the RetryPolicy and observe its decisions, see NewRetryDoer
+func RetriesWithPolicy(retryAttempts uint, backoff Backoff, options ...RetryOption) ClientOption {
 	return func(c Client) Client {
-		c.doer = NewRetryDoer(retryAttempts, backoffIntvl, maxJitterIntvl)
+		c.doer = newRetryDoer(retryAttempts, append([]RetryOption{WithBackoff(backoff)}, options...)...)
 		return c
 	}
 }
 
+// WithMiddleware appends middlewares around the client Doer, which remains the
+// innermost layer that sends the request, so options replacing it like Retries or
+// MockDoer keep the installed middlewares.
+//
+// Middlewares are applied in order, the first one installed is the outermost and
+// receives the request first and the response last.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c Client) Client {
+		c.middlewares = append(c.middlewares[:len(c.middlewares):len(c.middlewares)], middlewares...)
+		return c
+	}
+}
+
+// RateLimit specifies a token bucket that limits the requests made by the Client,
+// waiting before each request until it's allowed or its context is done.
+//
+// RequestsPerSecond: type float64 specifies the average amount of requests per second, zero means no limit
+//
+// Burst: type uint specifies the maximum amount of requests allowed at once
+//
+// Options: type RateLimitOption allows separate limits per HTTP method and to observe the waits
+//
+// The rate limiter is installed as a Middleware, see WithMiddleware.
+func RateLimit(requestsPerSecond float64, burst uint, options ...RateLimitOption) ClientOption {
+	return WithMiddleware(rateLimitMiddleware(requestsPerSecond, burst, options...))
+}
+
+// OAuth2ClientCredentials authenticates the requests made by the Client with bearer
+// tokens obtained from the tokenURL with the OAuth2 client credentials grant.
+//
+// Tokens are cached until shortly before they expire and a request rejected with 401 is
+// retried once with a new token. The TokenSource is installed as a Middleware, see
+// NewTokenSource to share it between Clients.
+func OAuth2ClientCredentials(tokenURL, clientID, clientSecret string, options ...TokenOption) ClientOption {
+	return WithMiddleware(NewTokenSource(tokenURL, clientID, clientSecret, options...).Middleware())
+}
+
+// StrictEnums makes the Client reject unknown account statuses, classifications,
+// countries and currencies with ErrUnknownEnumValue, both before sending a request
+// and after decoding a response. By default any value is sent and accepted.
+//
+// Models encoded or decoded outside the Client can be checked with their ValidateEnums method.
+func StrictEnums() ClientOption {
+	return func(c Client) Client {
+		c.strictEnums = true
+		return c
+	}
+}
+
+// ValidateRequests makes the Client check the accounts to create against the rules of
+// their country before any request is made, see ValidateAccountRequest. By default
+// accounts are only validated by the API.
+func ValidateRequests() ClientOption {
+	return func(c Client) Client {
+		c.validate = true
+		return c
+	}
+}
+
+// IdempotencyKeys makes the Client send a random Idempotency-Key header on every POST,
+// PATCH and DELETE request without a key provided with ContextWithIdempotencyKey.
+//
+// The key is kept across the retry attempts of the request and can be obtained from
+// the returned error with IdempotencyKeyFromError.
+func IdempotencyKeys() ClientOption {
+	return func(c Client) Client {
+		c.idempotencyKeys = true
+		return c
+	}
+}
+
+// RootCAs specifies PEM encoded certificate authorities trusted to verify the API
+// server certificate instead of the system ones. It can be used several times to
+// trust several authorities.
+//
+// Failures are returned wrapping ErrTLSConfig by every call made with the Client.
+func RootCAs(pemCerts []byte) ClientOption {
+	return func(c Client) Client {
+		return withTLS(c, func(config *tls.Config) error {
+			pool := x509.NewCertPool()
+			if config.RootCAs != nil {
+				pool = config.RootCAs.Clone()
+			}
+
+			if !pool.AppendCertsFromPEM(pemCerts) {
+				return errors.New("no PEM certificates found")
+			}
+
+			config.RootCAs = pool
+			return nil
+		})
+	}
+}
+
+// RootCAsFromFile specifies a PEM file with the certificate authorities trusted to
+// verify the API server certificate, see RootCAs.
+func RootCAsFromFile(path string) ClientOption {
+	return func(c Client) Client {
+		pemCerts, err := os.ReadFile(path)
+		if err != nil {
+			return withTLS(c, func(*tls.Config) error { return err })
+		}
+
+		return RootCAs(pemCerts)(c)
+	}
+}
+
+// ClientCertificate specifies the PEM encoded certificate and private key presented
+// to the API when it requests mutual TLS authentication.
+//
+// Failures are returned wrapping ErrTLSConfig by every call made with the Client.
+func ClientCertificate(certPEM, keyPEM []byte) ClientOption {
+	return func(c Client) Client {
+		return withTLS(c, func(config *tls.Config) error {
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			if err != nil {
+				return err
+			}
+
+			config.Certificates = []tls.Certificate{cert}
+			config.GetClientCertificate = nil
+			return nil
+		})
+	}
+}
+
+// ClientCertificateFromFiles specifies the PEM files of the certificate and private key
+// presented to the API when it requests mutual TLS authentication.
+//
+// The files are reloaded on the next TLS handshake after they change on disk, so rotated
+// certificates are used without restarting. While the new files can't be loaded, i.e.
+// only one of them has been replaced yet, the previous certificate is kept.
+func ClientCertificateFromFiles(certFile, keyFile string) ClientOption {
+	return func(c Client) Client {
+		return withTLS(c, func(config *tls.Config) error {
+			reloader := &certificateReloader{certFile: certFile, keyFile: keyFile}
+			if err := reloader.reload(); err != nil {
+				return err
+			}
+
+			config.Certificates = nil
+			config.GetClientCertificate = reloader.GetClientCertificate
+			return nil
+		})
+	}
+}
+
+// MinTLSVersion specifies the minimum TLS version accepted by the Client, one of the
+// tls.VersionTLS* constants, by default TLS 1.2.
+func MinTLSVersion(version uint16) ClientOption {
+	return func(c Client) Client {
+		return withTLS(c, func(config *tls.Config) error {
+			if version < tls.VersionTLS10 || version > tls.VersionTLS13 {
+				return fmt.Errorf("unknown TLS version %#x", version)
+			}
+
+			config.MinVersion = version
+			return nil
+		})
+	}
+}
+
 // MockDoer will provided the posibility to mock the client Doer that allows to
 // mock the Do func for testing purposes.
 //
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 
 	f3Client "form3-client-library"
@@ -76,7 +78,41 @@ func TestFetch_WhenRequestAndUnmarshalError_ThenFailsWithNormalizedErr(t *testin
 
 	assert.Equal(t, expReqURL, sendReqURL)
 	assert.Equal(t, f3Client.Account{}, account)
-	assert.EqualError(t, err, f3Client.ErrUnmarshalInvalidValue.Error())
+	var requestErr f3Client.RequestError
+	assert.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, http.StatusBadRequest, requestErr.StatusCode)
+	assert.ErrorIs(t, err, f3Client.ErrUnmarshalInvalidValue)
+	assert.ErrorIs(t, err, f3Client.ErrBadRequest)
+}
+
+func TestFetch_WhenServerErrorWithoutJSONBody_ThenFailsWithRetryableRequestError(t *testing.T) {
+	var (
+		testAccountID = uuid.NewString()
+		body          = "<html><body><h1>502 Bad Gateway</h1></body></html>"
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Header:     http.Header{"X-Request-Id": []string{"req-1"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}
+
+		client = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := client.Fetch(context.Background(), testAccountID)
+
+	var requestErr f3Client.RequestError
+	assert.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, http.StatusBadGateway, requestErr.StatusCode)
+	assert.Equal(t, body, requestErr.Body)
+	assert.Equal(t, "req-1", requestErr.RequestID)
+	assert.Equal(t, http.MethodGet, requestErr.Method)
+	assert.Equal(t, "/v1/organisation/accounts/"+testAccountID, requestErr.Path)
+	assert.ErrorIs(t, err, f3Client.ErrServer)
+	assert.ErrorIs(t, err, f3Client.ErrUnmarshalInvalidValue)
+	assert.True(t, f3Client.IsRetryable(err))
 }
 
 func TestFetch_WhenRecordNotFound_ThenFailsWithNormalizedErr(t *testing.T) {
@@ -258,6 +294,115 @@ func TestDelete_WhenRecordDeleted_ThenSuccessWithNoContentResponse(t *testing.T)
 	assert.NoError(t, err)
 }
 
+func TestDeleteVersion_WhenVersionProvided_ThenSendsVersion(t *testing.T) {
+	var (
+		sendReqURL string
+		testID     = uuid.NewString()
+
+		expReqURL = fmt.Sprintf("%s/%s?version=7", baseURL, testID)
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			sendReqURL = req.URL.String()
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       http.NoBody,
+			}, nil
+		}
+
+		client = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	err := client.DeleteVersion(context.Background(), testID, 7)
+
+	assert.Equal(t, expReqURL, sendReqURL)
+	assert.NoError(t, err)
+}
+
+func TestDeleteVersion_WhenVersionConflict_ThenFailsWithErrVersionConflict(t *testing.T) {
+	var (
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusConflict,
+				Header:     http.Header{"X-Request-Id": {"request-1"}},
+				Body:       getReaderFromInterface(f3Client.ResponseError{ErrorMessage: "invalid version", ErrorCode: "version-conflict"}),
+			}, nil
+		}
+
+		client = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+		testID = uuid.NewString()
+	)
+
+	err := client.DeleteVersion(context.Background(), testID, 1)
+
+	var requestErr f3Client.RequestError
+	assert.True(t, errors.Is(err, f3Client.ErrVersionConflict))
+	assert.ErrorIs(t, err, f3Client.ErrConflict)
+	assert.ErrorAs(t, err, &requestErr)
+	assert.EqualError(t, err, f3Client.ErrVersionConflict.Error())
+	assert.Equal(t, "request-1", requestErr.RequestID)
+	assert.Equal(t, http.MethodDelete, requestErr.Method)
+	assert.Equal(t, "/v1/organisation/accounts/"+testID, requestErr.Path)
+	assert.Equal(t, "version-conflict", requestErr.ErrorCode)
+	assert.JSONEq(t, `{"error_message":"invalid version","error_code":"version-conflict"}`, requestErr.Body)
+}
+
+func TestDeleteCurrent_WhenAccountFound_ThenDeletesCurrentVersion(t *testing.T) {
+	var (
+		sendReqURLs []string
+		testID      = uuid.NewString()
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			sendReqURLs = append(sendReqURLs, req.URL.String())
+			if req.Method == http.MethodGet {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       getReaderFromInterface(f3Client.AccountResponse{Account: f3Client.Account{ID: testID, Version: 2}}),
+				}, nil
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       http.NoBody,
+			}, nil
+		}
+
+		client = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	err := client.DeleteCurrent(context.Background(), testID)
+
+	assert.Equal(t, []string{
+		fmt.Sprintf("%s/%s", baseURL, testID),
+		fmt.Sprintf("%s/%s?version=2", baseURL, testID),
+	}, sendReqURLs)
+	assert.NoError(t, err)
+}
+
+func TestDeleteCurrent_WhenRecordNotFound_ThenFailsWithoutDelete(t *testing.T) {
+	var (
+		calls  int
+		expErr = f3Client.RequestError{
+			StatusCode: http.StatusNotFound,
+			Err:        f3Client.ErrRecordNotFound,
+		}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			calls++
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       getReaderFromInterface(f3Client.ResponseError{ErrorMessage: "record does not exist"}),
+			}, nil
+		}
+
+		client = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	err := client.DeleteCurrent(context.Background(), uuid.NewString())
+
+	assert.Equal(t, 1, calls)
+	assert.EqualError(t, err, expErr.Error())
+}
+
 func TestCreate_WhenDoError_ThenFailsWithErr(t *testing.T) {
 	var (
 		sendReqURL string
@@ -315,6 +460,39 @@ func TestCreate_WhenBadRequest_ThenFailsWithNormalizedErr(t *testing.T) {
 	assert.EqualError(t, err, expErr.Error())
 }
 
+func TestCreate_WhenBadRequest_ThenValidationErrorKeepsFields(t *testing.T) {
+	var (
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body: getReaderFromInterface(f3Client.ResponseError{
+					ErrorMessage: "validation failure list:\nvalidation failure list:\n" +
+						"country in body is required\n" +
+						"attributes.name.0 in body should be at most 140 chars long\n" +
+						"id in body must be of type uuid: \"abc\"",
+				}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := c.Create(context.TODO(), f3Client.AccountRequest{})
+
+	var validationErr f3Client.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []f3Client.FieldError{
+		{Field: "country", Rule: "required", Message: "is required"},
+		{Field: "attributes.name.0", Rule: "max", Message: "should be at most 140 chars long"},
+		{Field: "id", Rule: "type", Message: `must be of type uuid: "abc"`},
+	}, validationErr.Fields())
+
+	var requestErr f3Client.RequestError
+	assert.True(t, errors.As(err, &requestErr))
+	assert.Equal(t, http.StatusBadRequest, requestErr.StatusCode)
+	assert.EqualError(t, requestErr, err.Error())
+}
+
 func TestCreate_WhenUnmarshalErr_ThenFailsWithNormalizedErr(t *testing.T) {
 	var (
 		sendReqURL string
@@ -404,6 +582,419 @@ func TestCreate_WhenAccountCreated_ThenSuccess(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestList_WhenNoOptions_ThenRequestsWithoutQuery(t *testing.T) {
+	var (
+		sendReqURL string
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			sendReqURL = req.URL.String()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       getReaderFromInterface(f3Client.AccountListResponse{}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	page, err := c.List(context.Background(), f3Client.ListOptions{})
+
+	assert.Equal(t, baseURL, sendReqURL)
+	assert.Empty(t, page.Accounts)
+	assert.False(t, page.HasNext())
+	assert.NoError(t, err)
+}
+
+func TestList_WhenPageAndFilters_ThenEncodesQuery(t *testing.T) {
+	var (
+		sendReqQuery url.Values
+
+		options = f3Client.ListOptions{
+			PageNumber: 2,
+			PageSize:   50,
+			Filter: f3Client.ListFilter{
+				BankID:        "400300",
+				BankIDCode:    "GBDSC",
+				AccountNumber: "41426819",
+				Iban:          "GB11NWBK40030041426819",
+				Country:       "GB",
+				CustomerID:    "customer-1",
+			},
+		}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			sendReqQuery = req.URL.Query()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       getReaderFromInterface(f3Client.AccountListResponse{}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	page, err := c.List(context.Background(), options)
+
+	assert.Equal(t, url.Values{
+		"page[number]":           {"2"},
+		"page[size]":             {"50"},
+		"filter[bank_id]":        {"400300"},
+		"filter[bank_id_code]":   {"GBDSC"},
+		"filter[account_number]": {"41426819"},
+		"filter[iban]":           {"GB11NWBK40030041426819"},
+		"filter[country]":        {"GB"},
+		"filter[customer_id]":    {"customer-1"},
+	}, sendReqQuery)
+	assert.Equal(t, uint(2), page.PageNumber)
+	assert.Equal(t, uint(50), page.PageSize)
+	assert.NoError(t, err)
+}
+
+func TestList_WhenAccountsFound_ThenSuccessWithPageAndLinks(t *testing.T) {
+	var (
+		accounts = []f3Client.Account{
+			{ID: uuid.NewString(), OrganisationID: uuid.NewString(), Type: "accounts"},
+			{ID: uuid.NewString(), OrganisationID: uuid.NewString(), Type: "accounts"},
+		}
+		links = f3Client.Links{
+			Self:  "/v1/organisation/accounts?page%5Bnumber%5D=1&page%5Bsize%5D=2",
+			First: "/v1/organisation/accounts?page%5Bnumber%5D=first&page%5Bsize%5D=2",
+			Prev:  "/v1/organisation/accounts?page%5Bnumber%5D=0&page%5Bsize%5D=2",
+			Next:  "/v1/organisation/accounts?page%5Bnumber%5D=2&page%5Bsize%5D=2",
+			Last:  "/v1/organisation/accounts?page%5Bnumber%5D=last&page%5Bsize%5D=2",
+		}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       getReaderFromInterface(f3Client.AccountListResponse{Accounts: accounts, Links: links}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	page, err := c.List(context.Background(), f3Client.ListOptions{PageNumber: 1, PageSize: 2})
+
+	assert.Equal(t, accounts, page.Accounts)
+	assert.Equal(t, links, page.Links)
+	assert.True(t, page.HasNext())
+	assert.NoError(t, err)
+}
+
+func TestList_WhenBadRequest_ThenFailsWithNormalizedErr(t *testing.T) {
+	var (
+		expErr = f3Client.RequestError{
+			StatusCode: http.StatusBadRequest,
+			Err:        errors.New("page size must be positive;"),
+		}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       getReaderFromInterface(f3Client.ResponseError{ErrorMessage: "page size must be positive"}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	page, err := c.List(context.Background(), f3Client.ListOptions{})
+
+	assert.Equal(t, f3Client.AccountPage{}, page)
+	assert.EqualError(t, err, expErr.Error())
+}
+
+func TestUpdate_WhenEmptyIDs_ThenFailsWithBadRequest(t *testing.T) {
+	c := f3Client.NewClient()
+
+	tests := []string{
+		"",
+		" ",
+		"  ",
+		"   ",
+	}
+
+	for _, test := range tests {
+		account, err := c.Update(context.Background(), test, f3Client.AccountRequest{})
+
+		assert.EqualError(t, err, f3Client.ErrRequiredID.Error())
+		assert.Equal(t, account, f3Client.Account{})
+	}
+}
+
+func TestUpdate_WhenNoVersion_ThenFailsWithBadRequest(t *testing.T) {
+	c := f3Client.NewClient()
+
+	account, err := c.Update(context.Background(), uuid.NewString(), f3Client.AccountRequest{})
+
+	assert.EqualError(t, err, f3Client.ErrRequiredVersion.Error())
+	assert.Equal(t, account, f3Client.Account{})
+}
+
+func TestUpdate_WhenVersionConflict_ThenFailsWithErrVersionConflict(t *testing.T) {
+	var (
+		version int64 = 1
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusConflict,
+				Header:     http.Header{"X-Request-Id": {"request-1"}},
+				Body:       getReaderFromInterface(f3Client.ResponseError{ErrorMessage: "invalid version", ErrorCode: "version-conflict"}),
+			}, nil
+		}
+
+		c      = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+		testID = uuid.NewString()
+	)
+
+	account, err := c.Update(context.Background(), testID, f3Client.AccountRequest{Version: &version})
+
+	var requestErr f3Client.RequestError
+	assert.Equal(t, f3Client.Account{}, account)
+	assert.ErrorIs(t, err, f3Client.ErrVersionConflict)
+	assert.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, "request-1", requestErr.RequestID)
+	assert.Equal(t, http.MethodPatch, requestErr.Method)
+	assert.Equal(t, "/v1/organisation/accounts/"+testID, requestErr.Path)
+	assert.Equal(t, "version-conflict", requestErr.ErrorCode)
+}
+
+func TestUpdate_WhenVersionConflictWithoutJSONBody_ThenFailsWithErrVersionConflict(t *testing.T) {
+	var (
+		version int64 = 1
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{StatusCode: http.StatusConflict, Body: io.NopCloser(strings.NewReader("conflict"))}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := c.Update(context.Background(), uuid.NewString(), f3Client.AccountRequest{Version: &version})
+
+	var requestErr f3Client.RequestError
+	assert.ErrorIs(t, err, f3Client.ErrVersionConflict)
+	assert.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, http.MethodPatch, requestErr.Method)
+}
+
+func TestUpdate_WhenAccountUpdated_ThenSuccessWithAccountDetail(t *testing.T) {
+	var (
+		sendReqURL    string
+		sendReqMethod string
+		sendReqBody   f3Client.UpdateAccountRequest
+		version       int64 = 3
+		testAccountID       = uuid.NewString()
+
+		patch = f3Client.AccountRequest{
+			OrganisationID: uuid.NewString(),
+			Type:           "accounts",
+			Version:        &version,
+			Attributes: &f3Client.AccountAttributesRequest{
+				Country: "AR",
+				Name:    []string{"updated_name"},
+			},
+		}
+		expAccount = f3Client.Account{
+			ID:             testAccountID,
+			OrganisationID: patch.OrganisationID,
+			Type:           "accounts",
+			Version:        4,
+			AccountAttributes: f3Client.AccountAttributes{
+				Country: "AR",
+				Name:    []string{"updated_name"},
+			},
+		}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			sendReqURL = req.URL.String()
+			sendReqMethod = req.Method
+			_ = json.NewDecoder(req.Body).Decode(&sendReqBody)
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       getReaderFromInterface(f3Client.AccountResponse{Account: expAccount}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	account, err := c.Update(context.Background(), testAccountID, patch)
+
+	expPatch := f3Client.AccountPatch{
+		ID:             testAccountID,
+		OrganisationID: patch.OrganisationID,
+		Type:           "accounts",
+		Version:        &version,
+		Attributes: &f3Client.AccountAttributesPatch{
+			Country: "AR",
+			Name:    []string{"updated_name"},
+		},
+	}
+
+	assert.Equal(t, fmt.Sprintf("%s/%s", baseURL, testAccountID), sendReqURL)
+	assert.Equal(t, http.MethodPatch, sendReqMethod)
+	assert.Equal(t, expPatch, sendReqBody.Data)
+	assert.Equal(t, expAccount, account)
+	assert.NoError(t, err)
+}
+
+func TestUpdate_WhenPartialPatch_ThenOnlySendsSetFields(t *testing.T) {
+	var (
+		sendReqBody string
+		version     int64 = 1
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			body, _ := io.ReadAll(req.Body)
+			sendReqBody = string(body)
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       getReaderFromInterface(f3Client.AccountResponse{}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := c.Update(context.Background(), "id1", f3Client.AccountRequest{
+		Version:    &version,
+		Attributes: &f3Client.AccountAttributesRequest{Bic: "NWBKGB22"},
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"attributes":{"bic":"NWBKGB22"},"id":"id1","version":1}}`, sendReqBody)
+}
+
+func TestModify_WhenVersionConflict_ThenRefetchesAndRetries(t *testing.T) {
+	var (
+		fetches     int
+		sentVersion []int64
+		testID      = uuid.NewString()
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			if req.Method == http.MethodGet {
+				fetches++
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       getReaderFromInterface(f3Client.AccountResponse{Account: f3Client.Account{ID: testID, Version: fetches}}),
+				}, nil
+			}
+
+			var body f3Client.UpdateAccountRequest
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			sentVersion = append(sentVersion, *body.Data.Version)
+
+			if len(sentVersion) == 1 {
+				return &http.Response{StatusCode: http.StatusConflict, Body: http.NoBody}, nil
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       getReaderFromInterface(f3Client.AccountResponse{Account: f3Client.Account{ID: testID, Version: fetches + 1}}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	account, err := c.Modify(context.Background(), testID, 3, func(account f3Client.Account) (f3Client.AccountRequest, error) {
+		return f3Client.AccountRequest{Type: "accounts"}, nil
+	})
+
+	assert.Equal(t, 2, fetches)
+	assert.Equal(t, []int64{1, 2}, sentVersion)
+	assert.Equal(t, f3Client.Account{ID: testID, Version: 3}, account)
+	assert.NoError(t, err)
+}
+
+func TestModify_WhenPatchOmitsIdentity_ThenSendsFetchedIdentity(t *testing.T) {
+	var (
+		sendReqBody f3Client.UpdateAccountRequest
+		fetched     = f3Client.Account{ID: uuid.NewString(), OrganisationID: uuid.NewString(), Type: "accounts", Version: 2}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			if req.Method == http.MethodPatch {
+				_ = json.NewDecoder(req.Body).Decode(&sendReqBody)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       getReaderFromInterface(f3Client.AccountResponse{Account: fetched}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := c.Modify(context.Background(), fetched.ID, 1, func(account f3Client.Account) (f3Client.AccountRequest, error) {
+		return f3Client.AccountRequest{Type: "other", Attributes: &f3Client.AccountAttributesRequest{Bic: "NWBKGB22"}}, nil
+	})
+
+	version := int64(2)
+	assert.NoError(t, err)
+	assert.Equal(t, f3Client.AccountPatch{
+		ID:             fetched.ID,
+		OrganisationID: fetched.OrganisationID,
+		Type:           "accounts",
+		Version:        &version,
+		Attributes:     &f3Client.AccountAttributesPatch{Bic: "NWBKGB22"},
+	}, sendReqBody.Data)
+}
+
+func TestModify_WhenAttemptsReached_ThenFailsWithErrVersionConflict(t *testing.T) {
+	var (
+		updates int
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			if req.Method == http.MethodGet {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       getReaderFromInterface(f3Client.AccountResponse{}),
+				}, nil
+			}
+
+			updates++
+			return &http.Response{StatusCode: http.StatusConflict, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	account, err := c.Modify(context.Background(), uuid.NewString(), 2, func(account f3Client.Account) (f3Client.AccountRequest, error) {
+		return f3Client.AccountRequest{}, nil
+	})
+
+	assert.Equal(t, 2, updates)
+	assert.Equal(t, f3Client.Account{}, account)
+	assert.ErrorIs(t, err, f3Client.ErrVersionConflict)
+}
+
+func TestModify_WhenModifyErr_ThenFailsWithoutUpdate(t *testing.T) {
+	var (
+		expErr = errors.New("modify error")
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			assert.Equal(t, http.MethodGet, req.Method)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       getReaderFromInterface(f3Client.AccountResponse{}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	account, err := c.Modify(context.Background(), uuid.NewString(), 1, func(account f3Client.Account) (f3Client.AccountRequest, error) {
+		return f3Client.AccountRequest{}, expErr
+	})
+
+	assert.Equal(t, f3Client.Account{}, account)
+	assert.EqualError(t, err, expErr.Error())
+}
+
 func getReaderFromInterface(i interface{}) io.ReadCloser {
 	b, _ := json.Marshal(&i)
 	return io.NopCloser(bytes.NewBufferString(string(b)))
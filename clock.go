@@ -0,0 +1,24 @@
+package form3client
+
+import "time"
+
+// Clock provides the current time and timers used to wait between attempts, allowing
+// to replace the real time so retry timing can be tested deterministically.
+type Clock interface {
+	Now() time.Time
+
+	// NewTimer returns a channel that receives the current time once d has elapsed
+	// and a stop func that releases the timer if it's no longer needed.
+	NewTimer(d time.Duration) (<-chan time.Time, func() bool)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	timer := time.NewTimer(d)
+	return timer.C, timer.Stop
+}
@@ -1,21 +1,81 @@
 package form3client
 
 import (
-	"math"
-	"math/rand"
+	"io"
 	"net/http"
-	"os"
 	"time"
 )
 
 const (
 	empty, noRetry = 0, 0
+
+	maxDiscardBody = 4 << 10
 )
 
 type retryDoer struct {
-	retryAttempts  int
-	backoffIntvl   int
-	maxJitterIntvl int
+	next          Doer
+	retryAttempts int
+	backoff       Backoff
+	policy        RetryPolicy
+	notify        func(attempt int, decision RetryDecision)
+	budget        time.Duration
+	clock         Clock
+}
+
+// RetryOption is any function that can work as an option to set the retry Doer
+// features, following the same Functional Options approach as ClientOption.
+type RetryOption func(retryDoer) retryDoer
+
+// WithRetryPolicy specifies the RetryPolicy that classifies every attempt, by
+// default the DefaultRetryPolicy using the retry Clock is used.
+func WithRetryPolicy(policy RetryPolicy) RetryOption {
+	return func(r retryDoer) retryDoer {
+		if policy != nil {
+			r.policy = policy
+		}
+		return r
+	}
+}
+
+// WithRetryNotify specifies a function called with the RetryDecision of every
+// attempt, allowing to log the reasons why a request was or wasn't retried.
+func WithRetryNotify(notify func(attempt int, decision RetryDecision)) RetryOption {
+	return func(r retryDoer) retryDoer {
+		r.notify = notify
+		return r
+	}
+}
+
+// WithBackoff specifies the Backoff strategy that computes the waits between
+// attempts, replacing the exponential backoff set up by NewRetryDoer.
+func WithBackoff(backoff Backoff) RetryOption {
+	return func(r retryDoer) retryDoer {
+		if backoff != nil {
+			r.backoff = backoff
+		}
+		return r
+	}
+}
+
+// WithRetryBudget specifies the overall time limit of all the attempts of a request,
+// including the waits between them. A retry whose wait would exceed the budget is not
+// made and ErrRetryBudget is returned. A budget of zero means no budget.
+func WithRetryBudget(budget time.Duration) RetryOption {
+	return func(r retryDoer) retryDoer {
+		r.budget = budget
+		return r
+	}
+}
+
+// WithRetryClock specifies the Clock used to measure the retry budget and wait between
+// attempts, by default the real time is used.
+func WithRetryClock(clock Clock) RetryOption {
+	return func(r retryDoer) retryDoer {
+		if clock != nil {
+			r.clock = clock
+		}
+		return r
+	}
 }
 
 // NewRetryDoer has the default implementation of the client Do strategy, implementing
@@ -23,23 +83,46 @@ type retryDoer struct {
 //
 // RetryAttempts: type uint specifies the amount of retries attempts
 //
-// BackoffInterval: type uint specifies the behind backoff interval in milliseconds
+// BackoffInterval: type uint specifies the behind backoff interval
 // and is to use progressively exponential longer waits between retries for consecutive error responses
 //
-// MaximumJitterInterval: type uint specifys the maximum jitter interval (randomized delay) in milliseconds to prevent successive collisions
+// MaximumJitterInterval: type uint specifys the maximum jitter interval (randomized delay) to prevent successive collisions
 // use in the exponential backoff interval algorithm
 //
+// Both intervals are converted to time.Duration as they are, so they are nanoseconds. The
+// unit is kept for compatibility with the existing clients, use RetriesWithBackoff to
+// specify the intervals as time.Duration.
+//
+// Options: type RetryOption allows to change the Backoff and RetryPolicy, observe its decisions,
+// limit the overall retry time and replace the Clock.
+//
 // Retries is consider default if any of the params is set to its zero/empty value, so it will not retry
-func NewRetryDoer(retryAttempts, backoffIntvl, maxJitterIntvl uint) Doer {
+func NewRetryDoer(retryAttempts, backoffIntvl, maxJitterIntvl uint, options ...RetryOption) Doer {
 	if retryAttempts == noRetry || maxJitterIntvl == empty || backoffIntvl == empty {
 		retryAttempts = noRetry
 	}
 
-	return retryDoer{
-		int(retryAttempts),
-		int(backoffIntvl),
-		int(maxJitterIntvl),
+	backoff := ExponentialBackoff(time.Duration(backoffIntvl), time.Duration(maxJitterIntvl), 0)
+
+	return newRetryDoer(retryAttempts, append([]RetryOption{WithBackoff(backoff)}, options...)...)
+}
+
+func newRetryDoer(retryAttempts uint, options ...RetryOption) retryDoer {
+	r := retryDoer{
+		retryAttempts: int(retryAttempts),
+		backoff:       ConstantBackoff(0),
+		clock:         realClock{},
 	}
+
+	for _, option := range options {
+		r = option(r)
+	}
+
+	if r.policy == nil {
+		r.policy = NewDefaultRetryPolicy(r.clock)
+	}
+
+	return r
 }
 
 // Do will execute the request with an retry strategy.
@@ -47,26 +130,123 @@ func NewRetryDoer(retryAttempts, backoffIntvl, maxJitterIntvl uint) Doer {
 // client (http.Client) the go standar net/http that sends the HTTP request.
 //
 // req (*http.Request) contains the request data
+//
+// Every attempt is classified by the RetryPolicy, when the retry attempts or budget are
+// reached after an error ErrRetryLimit or ErrRetryBudget is returned, while the last
+// response is returned as is.
+//
+// Waits between attempts stop as soon as the request context is done, returning its error.
+//
+// Requests with a body are only retried when it can be rewound with req.GetBody, as
+// set by http.NewRequest for in memory bodies, otherwise a single attempt is made.
 func (r retryDoer) Do(client http.Client, req *http.Request) (resp *http.Response, err error) {
-	retries := empty
-
-	if r.retryAttempts == noRetry {
-		return client.Do(req)
+	if r.retryAttempts == noRetry || !isRewindable(req) {
+		return r.send(client, req)
 	}
 
-	for ; retries < r.retryAttempts; retries++ {
-		resp, err = client.Do(req)
-		if err == nil || os.IsTimeout(err) {
-			break
+	var (
+		start        = r.clock.Now()
+		backoffIntvl time.Duration
+	)
+
+	for retries := empty; ; retries++ {
+		attemptReq := req
+		if retries > empty {
+			if attemptReq, err = rewindBody(req); err != nil {
+				return nil, err
+			}
 		}
 
-		backoffIntvl := int(float64(r.backoffIntvl)*math.Exp2(float64(retries))) + rand.Intn(r.maxJitterIntvl)
-		time.Sleep(time.Duration(float64(backoffIntvl)))
+		resp, err = r.send(client, attemptReq)
+
+		decision := r.policy.Decide(attemptReq, resp, err)
+		if r.notify != nil {
+			r.notify(retries+1, decision)
+		}
+
+		if !decision.Retry {
+			return resp, err
+		}
+
+		backoffIntvl = r.backoff.Next(retries+1, backoffIntvl)
+		if decision.Wait > 0 {
+			backoffIntvl = decision.Wait
+		}
+
+		var limit error
+		switch {
+		case retries+1 >= r.retryAttempts:
+			limit = ErrRetryLimit
+		case r.budget > 0 && r.clock.Now().Add(backoffIntvl).Sub(start) > r.budget:
+			limit = ErrRetryBudget
+		}
+
+		if limit != nil {
+			if err != nil {
+				return nil, retryLimitError{limit: limit, last: err}
+			}
+
+			return resp, nil
+		}
+
+		discardBody(resp)
+
+		if err := r.wait(req, backoffIntvl); err != nil {
+			return nil, err
+		}
 	}
+}
 
-	if retries == r.retryAttempts {
-		return resp, ErrRetryLimit
+// send makes a single attempt with the next Doer when the retry Doer is used as
+// a Middleware, otherwise with the client itself.
+func (r retryDoer) send(client http.Client, req *http.Request) (*http.Response, error) {
+	if r.next != nil {
+		return r.next.Do(client, req)
 	}
 
-	return resp, err
+	return client.Do(req)
+}
+
+// wait blocks for the backoff interval or until the request context is done.
+func (r retryDoer) wait(req *http.Request, backoffIntvl time.Duration) error {
+	timer, stop := r.clock.NewTimer(backoffIntvl)
+	defer stop()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer:
+		return nil
+	}
+}
+
+func discardBody(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDiscardBody))
+	resp.Body.Close()
+}
+
+func isRewindable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewindBody returns a copy of the request with a fresh body obtained
+// from req.GetBody, so every attempt sends the same bytes.
+func rewindBody(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	attemptReq := req.Clone(req.Context())
+	attemptReq.Body = body
+
+	return attemptReq, nil
 }
@@ -1,12 +1,17 @@
 package form3client_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	f3Client "form3-client-library"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -70,3 +75,254 @@ func TestDo_WhenRequestSuccessWithRetry_ThenReturnsResp(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestDo_WhenRetryingRequestWithBody_ThenEveryAttemptSendsSameBytes(t *testing.T) {
+	var (
+		sentBodies [][]byte
+		expBody    = []byte(`{"data":{"id":"test-id","type":"accounts"}}`)
+
+		client = http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			sentBodies = append(sentBodies, body)
+			return nil, errors.New("connection reset by peer")
+		})}
+	)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://accountapi:8080", bytes.NewReader(expBody))
+	doer := f3Client.NewRetryDoer(3, 1, 1)
+
+	resp, err := doer.Do(client, req)
+
+	assert.EqualError(t, err, f3Client.ErrRetryLimit.Error())
+	assert.Nil(t, resp)
+	assert.Equal(t, [][]byte{expBody, expBody, expBody}, sentBodies)
+}
+
+func TestDo_WhenBodyNotRewindable_ThenDoesNotRetry(t *testing.T) {
+	var (
+		sentBodies []string
+
+		client = http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			sentBodies = append(sentBodies, string(body))
+			return nil, errors.New("connection reset by peer")
+		})}
+	)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://accountapi:8080", io.NopCloser(strings.NewReader("payload")))
+	doer := f3Client.NewRetryDoer(3, 1, 1)
+
+	resp, err := doer.Do(client, req)
+
+	assert.ErrorContains(t, err, "connection reset by peer")
+	assert.Nil(t, resp)
+	assert.Equal(t, []string{"payload"}, sentBodies)
+}
+
+func TestDo_WhenRetryableStatus_ThenRetriesAndReturnsLastResp(t *testing.T) {
+	var (
+		attempts  int
+		decisions []f3Client.RetryDecision
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts++; attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	doer := f3Client.NewRetryDoer(3, 1, 1, f3Client.WithRetryNotify(func(attempt int, decision f3Client.RetryDecision) {
+		decisions = append(decisions, decision)
+	}))
+
+	resp, err := doer.Do(http.Client{}, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+	assert.Len(t, decisions, 3)
+	assert.True(t, decisions[0].Retry)
+	assert.Equal(t, "retryable status 503", decisions[0].Reason)
+	assert.False(t, decisions[2].Retry)
+}
+
+func TestDo_WhenRetryableStatusAndRetryLimitReached_ThenReturnsLastResp(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	doer := f3Client.NewRetryDoer(2, 1, 1)
+
+	resp, err := doer.Do(http.Client{}, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestDo_WhenNonIdempotentServerError_ThenDoesNotRetry(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader([]byte("{}")))
+	doer := f3Client.NewRetryDoer(3, 1, 1)
+
+	resp, err := doer.Do(http.Client{}, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestDo_WhenCustomRetryPolicy_ThenUsesItsDecisions(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	policy := f3Client.RetryPolicyFunc(func(req *http.Request, resp *http.Response, err error) f3Client.RetryDecision {
+		return f3Client.RetryDecision{Retry: resp != nil && resp.StatusCode == http.StatusNotFound, Reason: "eventual consistency"}
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	doer := f3Client.NewRetryDoer(4, 1, 1, f3Client.WithRetryPolicy(policy))
+
+	resp, err := doer.Do(http.Client{}, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, 4, attempts)
+}
+
+func TestDo_WhenRetrying_ThenWaitsWithClockWithoutRealSleeps(t *testing.T) {
+	var (
+		clock    = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+		attempts int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	doer := f3Client.NewRetryDoer(3, 1, 1, f3Client.WithRetryClock(clock))
+
+	resp, err := doer.Do(http.Client{}, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []time.Duration{30 * time.Second, 30 * time.Second}, clock.waits)
+}
+
+func TestDo_WhenRetryBudgetExhausted_ThenStopsRetrying(t *testing.T) {
+	var (
+		clock    = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+		attempts int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "45")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	doer := f3Client.NewRetryDoer(5, 1, 1, f3Client.WithRetryClock(clock), f3Client.WithRetryBudget(time.Minute))
+
+	resp, err := doer.Do(http.Client{}, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []time.Duration{45 * time.Second}, clock.waits)
+}
+
+func TestDo_WhenRetryBudgetExhaustedAfterErr_ThenFailsWithErrRetryBudget(t *testing.T) {
+	var (
+		clock  = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+		client = http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			clock.now = clock.now.Add(time.Second)
+			return nil, errors.New("connection refused")
+		})}
+	)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://accountapi:8080", nil)
+	doer := f3Client.NewRetryDoer(10, 1, 1, f3Client.WithRetryClock(clock), f3Client.WithRetryBudget(3*time.Second))
+
+	resp, err := doer.Do(client, req)
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, f3Client.ErrRetryBudget)
+	assert.ErrorContains(t, err, f3Client.ErrRetryBudget.Error())
+	assert.Len(t, clock.waits, 2)
+}
+
+func TestDo_WhenContextCancelledWhileWaiting_ThenReturnsCtxErr(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	doer := f3Client.NewRetryDoer(2, 1, 1)
+
+	start := time.Now()
+	resp, err := doer.Do(http.Client{}, req)
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+// fakeClock records the waits requested by the retry Doer and advances
+// its time instead of sleeping.
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+
+	fired := make(chan time.Time, 1)
+	fired <- c.now
+
+	return fired, func() bool { return false }
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
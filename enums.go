@@ -0,0 +1,203 @@
+package form3client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AccountStatus is the status of an account resource.
+type AccountStatus string
+
+const (
+	AccountStatusPending   AccountStatus = "pending"
+	AccountStatusConfirmed AccountStatus = "confirmed"
+	AccountStatusFailed    AccountStatus = "failed"
+	AccountStatusClosed    AccountStatus = "closed"
+)
+
+// Valid reports whether the status is one of the statuses known by the API.
+func (s AccountStatus) Valid() bool {
+	switch s {
+	case AccountStatusPending, AccountStatusConfirmed, AccountStatusFailed, AccountStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// AccountClassification is the classification of an account resource.
+type AccountClassification string
+
+const (
+	ClassificationPersonal AccountClassification = "Personal"
+	ClassificationBusiness AccountClassification = "Business"
+)
+
+// Valid reports whether the classification is one of the classifications known by the API.
+func (c AccountClassification) Valid() bool {
+	return c == ClassificationPersonal || c == ClassificationBusiness
+}
+
+// Country is an ISO 3166-1 alpha-2 country code, constants are provided for the
+// countries supported by the Form3 account API.
+type Country string
+
+const (
+	CountryAustralia     Country = "AU"
+	CountryBelgium       Country = "BE"
+	CountryCanada        Country = "CA"
+	CountryFrance        Country = "FR"
+	CountryGermany       Country = "DE"
+	CountryGreece        Country = "GR"
+	CountryHongKong      Country = "HK"
+	CountryItaly         Country = "IT"
+	CountryLuxembourg    Country = "LU"
+	CountryNetherlands   Country = "NL"
+	CountryPoland        Country = "PL"
+	CountryPortugal      Country = "PT"
+	CountrySpain         Country = "ES"
+	CountrySwitzerland   Country = "CH"
+	CountryUnitedKingdom Country = "GB"
+	CountryUnitedStates  Country = "US"
+)
+
+// Valid reports whether the country is an assigned ISO 3166-1 alpha-2 code.
+func (c Country) Valid() bool {
+	_, ok := countries[c]
+	return ok
+}
+
+// Currency is an ISO 4217 currency code, constants are provided for the most
+// common currencies of the countries supported by the Form3 account API.
+type Currency string
+
+const (
+	CurrencyAUD Currency = "AUD"
+	CurrencyCAD Currency = "CAD"
+	CurrencyCHF Currency = "CHF"
+	CurrencyEUR Currency = "EUR"
+	CurrencyGBP Currency = "GBP"
+	CurrencyHKD Currency = "HKD"
+	CurrencyPLN Currency = "PLN"
+	CurrencyUSD Currency = "USD"
+)
+
+// Valid reports whether the currency is an active ISO 4217 code.
+func (c Currency) Valid() bool {
+	_, ok := currencies[c]
+	return ok
+}
+
+var (
+	countries = newEnumSet[Country](`
+		AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ
+		BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM
+		DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS
+		GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN
+		KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ
+		MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM
+		PN PR PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV
+		SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI
+		VN VU WF WS YE YT ZA ZM ZW`)
+
+	currencies = newEnumSet[Currency](`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL BSD BTN BWP
+		BYN BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP
+		GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR
+		KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK
+		MXN MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR
+		SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS
+		UAH UGX USD UYU UZS VES VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL`)
+)
+
+func newEnumSet[T ~string](codes string) map[T]struct{} {
+	set := make(map[T]struct{})
+	for _, code := range strings.Fields(codes) {
+		set[T(code)] = struct{}{}
+	}
+
+	return set
+}
+
+// enumValidator is implemented by the models that contain enum values, so the Client
+// can reject unknown values when StrictEnums is set.
+type enumValidator interface {
+	ValidateEnums() error
+}
+
+// ValidateEnums reports the first unknown enum value of the account with ErrUnknownEnumValue,
+// so a request can be checked as the Client does with StrictEnums.
+func (r CreateAccountRequest) ValidateEnums() error {
+	return r.Data.ValidateEnums()
+}
+
+// ValidateEnums reports the first unknown enum value of the patch with ErrUnknownEnumValue.
+func (r UpdateAccountRequest) ValidateEnums() error {
+	return r.Data.ValidateEnums()
+}
+
+// ValidateEnums reports the first unknown enum value of the attributes with ErrUnknownEnumValue,
+// empty values are considered unset and accepted.
+func (r AccountRequest) ValidateEnums() error {
+	if r.Attributes == nil {
+		return nil
+	}
+
+	a := r.Attributes
+
+	return validateEnums(a.AccountClassification, a.Status, a.Country, a.BaseCurrency)
+}
+
+// ValidateEnums reports the first unknown enum value of the attributes with ErrUnknownEnumValue,
+// empty values are considered unset and accepted.
+func (p AccountPatch) ValidateEnums() error {
+	if p.Attributes == nil {
+		return nil
+	}
+
+	a := p.Attributes
+
+	return validateEnums(a.AccountClassification, a.Status, a.Country, a.BaseCurrency)
+}
+
+// ValidateEnums reports the first unknown enum value of the account with ErrUnknownEnumValue,
+// so a response decoded outside the Client can be checked as the Client does with StrictEnums.
+func (r AccountResponse) ValidateEnums() error {
+	return r.Account.ValidateEnums()
+}
+
+// ValidateEnums reports the first unknown enum value of the accounts with ErrUnknownEnumValue.
+func (r AccountListResponse) ValidateEnums() error {
+	for _, account := range r.Accounts {
+		if err := account.ValidateEnums(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateEnums reports the first unknown enum value of the attributes with ErrUnknownEnumValue,
+// empty values are considered unset and accepted.
+func (a Account) ValidateEnums() error {
+	attributes := a.AccountAttributes
+
+	return validateEnums(attributes.AccountClassification, attributes.Status, attributes.Country, attributes.BaseCurrency)
+}
+
+// validateEnums checks the enum values of the account attributes, empty values are
+// considered unset and accepted.
+func validateEnums(classification *AccountClassification, status *AccountStatus, country Country, currency Currency) error {
+	switch {
+	case classification != nil && !classification.Valid():
+		return fmt.Errorf("%w: account_classification %q", ErrUnknownEnumValue, *classification)
+	case status != nil && !status.Valid():
+		return fmt.Errorf("%w: status %q", ErrUnknownEnumValue, *status)
+	case country != "" && !country.Valid():
+		return fmt.Errorf("%w: country %q", ErrUnknownEnumValue, country)
+	case currency != "" && !currency.Valid():
+		return fmt.Errorf("%w: base_currency %q", ErrUnknownEnumValue, currency)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+package form3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnums_WhenValid_ThenOnlyKnownValues(t *testing.T) {
+	assert.True(t, f3Client.AccountStatusConfirmed.Valid())
+	assert.False(t, f3Client.AccountStatus("Confirmed").Valid())
+
+	assert.True(t, f3Client.ClassificationBusiness.Valid())
+	assert.False(t, f3Client.AccountClassification("business").Valid())
+
+	assert.True(t, f3Client.CountryUnitedKingdom.Valid())
+	assert.True(t, f3Client.Country("AR").Valid())
+	assert.False(t, f3Client.Country("UK").Valid())
+
+	assert.True(t, f3Client.CurrencyGBP.Valid())
+	assert.True(t, f3Client.Currency("JPY").Valid())
+	assert.False(t, f3Client.Currency("GPB").Valid())
+}
+
+func TestStrictEnums_WhenUnknownRequestValue_ThenFailsBeforeSending(t *testing.T) {
+	var (
+		calls int
+
+		req = f3Client.AccountRequest{
+			ID:             uuid.NewString(),
+			OrganisationID: uuid.NewString(),
+			Type:           "accounts",
+			Attributes: &f3Client.AccountAttributesRequest{
+				Country:      f3Client.CountryUnitedKingdom,
+				BaseCurrency: "GPB",
+				Name:         []string{"name_test"},
+			},
+		}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			calls++
+			return &http.Response{StatusCode: http.StatusCreated, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc), f3Client.StrictEnums())
+	)
+
+	_, err := c.Create(context.TODO(), req)
+
+	assert.ErrorIs(t, err, f3Client.ErrUnknownEnumValue)
+	assert.EqualError(t, err, `unknown enum value: base_currency "GPB"`)
+	assert.Equal(t, 0, calls)
+}
+
+func TestStrictEnums_WhenUnknownResponseValue_ThenFails(t *testing.T) {
+	var (
+		status = f3Client.AccountStatus("archived")
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: getReaderFromInterface(f3Client.AccountResponse{Account: f3Client.Account{
+					ID:                uuid.NewString(),
+					AccountAttributes: f3Client.AccountAttributes{Status: &status},
+				}}),
+			}, nil
+		}
+
+		lenient = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+		strict  = f3Client.NewClient(f3Client.MockDoer(doerMockFunc), f3Client.StrictEnums())
+	)
+
+	account, err := lenient.Fetch(context.TODO(), uuid.NewString())
+	assert.NoError(t, err)
+	assert.Equal(t, status, *account.AccountAttributes.Status)
+
+	account, err = strict.Fetch(context.TODO(), uuid.NewString())
+	assert.ErrorIs(t, err, f3Client.ErrUnknownEnumValue)
+	assert.Equal(t, f3Client.Account{}, account)
+}
+
+func TestValidateEnums_WhenDecodedOutsideClient_ThenReportsUnknownValues(t *testing.T) {
+	var response f3Client.AccountResponse
+	assert.NoError(t, json.Unmarshal([]byte(`{"data":{"attributes":{"country":"UK","status":"confirmed"}}}`), &response))
+
+	err := response.ValidateEnums()
+	assert.ErrorIs(t, err, f3Client.ErrUnknownEnumValue)
+	assert.EqualError(t, err, `unknown enum value: country "UK"`)
+
+	response.Account.AccountAttributes.Country = f3Client.CountryUnitedKingdom
+	assert.NoError(t, response.ValidateEnums())
+
+	classification := f3Client.AccountClassification("business")
+	request := f3Client.AccountRequest{Attributes: &f3Client.AccountAttributesRequest{Country: "GB", AccountClassification: &classification}}
+	assert.ErrorIs(t, request.ValidateEnums(), f3Client.ErrUnknownEnumValue)
+
+	assert.NoError(t, f3Client.AccountRequest{}.ValidateEnums())
+}
@@ -2,21 +2,76 @@ package form3client
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"regexp"
 	"strings"
 )
 
+// RequestError is the error returned for a response with an unexpected status code.
+//
+// It wraps both its Err and the sentinel of its status code, so errors.Is can be used to
+// check either of them, i.e. errors.Is(err, ErrRecordNotFound) for any 404 response.
+//
+// The details of the failed request are kept for support tickets:
+//
+// RequestID: value of the X-Request-Id response header.
+//
+// Method and Path: HTTP method and URL path of the request, without its query.
+//
+// ErrorCode: error_code field of the response body, if any.
+//
+// Body: raw response body, truncated to 4KB. It may contain account data, so it's
+// redacted when the error is formatted with %+v.
+//
+// IdempotencyKey: value of the Idempotency-Key request header, to replay the request.
 type RequestError struct {
-	StatusCode int
-	Err        error
+	StatusCode     int
+	Err            error
+	RequestID      string
+	Method         string
+	Path           string
+	ErrorCode      string
+	Body           string
+	IdempotencyKey string
 }
 
 func (re RequestError) Error() string {
 	return fmt.Sprintf("status:%d, error:'%s'.", re.StatusCode, re.Err.Error())
 }
 
+// Format prints the Error message with %v and %s, while %+v adds the request details
+// with the account attributes of the body redacted, intended for logs.
+func (re RequestError) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "%s method:%s, path:%s, request_id:%s, idempotency_key:%s, error_code:%s, body:%s",
+			re.Error(), re.Method, re.Path, re.RequestID, re.IdempotencyKey, re.ErrorCode, redactBody(re.Body))
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", re.Error())
+	default:
+		fmt.Fprint(f, re.Error())
+	}
+}
+
+// Unwrap returns the Err and the sentinel of the status code, if any.
+func (re RequestError) Unwrap() []error {
+	errs := make([]error, 0, 2)
+	if re.Err != nil {
+		errs = append(errs, re.Err)
+	}
+
+	if statusErr := statusError(re.StatusCode); statusErr != nil && statusErr != re.Err {
+		errs = append(errs, statusErr)
+	}
+
+	return errs
+}
+
 var (
 	// ErrUnmarshalInvalidValue signals that the received JSON value
 	// could not be converted to its selected interface using json.Unmarshal.
@@ -26,6 +81,38 @@ var (
 	// limit of retry attempts was reached.
 	ErrRetryLimit = errors.New("unable to execute request, retry attempts reached")
 
+	// ErrRetryBudget signals the failure to execute the request and the
+	// retry budget was exhausted before the next attempt.
+	ErrRetryBudget = errors.New("unable to execute request, retry budget exhausted")
+
+	// ErrCircuitOpen signals that the request was not made because the CircuitBreaker
+	// is open after too many failures of the API.
+	ErrCircuitOpen = errors.New("unable to execute request, circuit breaker is open")
+
+	// ErrUnknownEnumValue signals that a status, classification, country or currency is not
+	// one of the known values, only returned when the ClientOption StrictEnums is set.
+	ErrUnknownEnumValue = errors.New("unknown enum value")
+
+	// ErrInvalidAccount signals that the account failed the client side validation, the
+	// failed fields can be obtained with errors.As and FieldErrors.
+	ErrInvalidAccount = errors.New("invalid account")
+
+	// ErrTokenRequest signals the failure to obtain an OAuth2 token from the token URL,
+	// see TokenSource.
+	ErrTokenRequest = errors.New("unable to obtain OAuth2 token")
+
+	// ErrUnsupportedKey signals that a signing key is not an RSA or Ed25519 key in a
+	// supported PEM format, see RequestSigner.
+	ErrUnsupportedKey = errors.New("unsupported signing key")
+
+	// ErrInvalidSignature signals that the HTTP signature of a request is missing or
+	// doesn't match its content, see VerifyRequestSignature.
+	ErrInvalidSignature = errors.New("invalid HTTP signature")
+
+	// ErrTLSConfig signals that a TLS ClientOption couldn't load its certificates or keys,
+	// it's returned by every call made with the Client.
+	ErrTLSConfig = errors.New("invalid TLS configuration")
+
 	// ErrSerializeRequest signals the failure while trying to encode an object with
 	// json.Marshal operation.
 	ErrSerializeRequest = errors.New("an error happened while trying to serialize")
@@ -37,39 +124,306 @@ var (
 		Err:        errors.New("an id must be provided and can't contain only blanks"),
 	}
 
+	// ErrRequiredVersion signals that in order to continue the user must provide the
+	// current version of the resource to modify.
+	ErrRequiredVersion = RequestError{
+		StatusCode: http.StatusBadRequest,
+		Err:        errors.New("the current version of the resource must be provided"),
+	}
+
+	// ErrVersionConflict signals that the provided version of the resource doesn't match
+	// its current version, so it was modified since it was read.
+	ErrVersionConflict = RequestError{
+		StatusCode: http.StatusConflict,
+		Err:        errors.New("invalid version, the resource was modified"),
+	}
+
 	// ErrTimeout signals that the request was cancel do to reach the specified timeout,
 	// client timeout can be change withe de ClientOption -> Timeout() and
 	// for more settings information review client options.
 	ErrTimeout = errors.New("request cancel due to context timeout deadline exceeded")
 
+	// ErrBadRequest signals a 400 response, the request is invalid and must not be retried as is.
+	ErrBadRequest = errors.New("bad request")
+
+	// ErrUnauthorized signals a 401 response, the request credentials are missing or invalid.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden signals a 403 response, the credentials don't allow the request.
+	ErrForbidden = errors.New("forbidden")
+
 	// ErrRecordNotFound signals that the requested resource is not available or does not
 	// exist.
 	ErrRecordNotFound = errors.New("record does not exist")
+
+	// ErrConflict signals a 409 response, the request conflicts with the current state
+	// of the resource, i.e. a duplicated id or a modified version.
+	ErrConflict = errors.New("conflict")
+
+	// ErrAccountMismatch signals that an account with the same ID already exists
+	// with different attributes, see AccountMismatchError.
+	ErrAccountMismatch = errors.New("existing account doesn't match the request")
+
+	// ErrTooManyRequests signals a 429 response, the API is rate limiting the client.
+	ErrTooManyRequests = errors.New("too many requests")
+
+	// ErrServer signals a 5xx response, the API failed to process the request.
+	ErrServer = errors.New("server error")
 )
 
-func handleResponseError(resp *http.Response) error {
-	var respErr ResponseError
-	if err := unmarshalBody(resp.Body, &respErr); err != nil {
+// statusError returns the sentinel of an HTTP status code, nil when it has none.
+func statusError(statusCode int) error {
+	switch {
+	case statusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case statusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrForbidden
+	case statusCode == http.StatusNotFound:
+		return ErrRecordNotFound
+	case statusCode == http.StatusConflict:
+		return ErrConflict
+	case statusCode == http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case statusCode >= http.StatusInternalServerError:
+		return ErrServer
+	default:
+		return nil
+	}
+}
+
+// versionConflictError is the Err of the RequestError returned for a version conflict,
+// it wraps ErrVersionConflict so errors.Is matches it while keeping its message.
+type versionConflictError struct{}
+
+func (versionConflictError) Error() string {
+	return ErrVersionConflict.Err.Error()
+}
+
+func (versionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// timeoutError keeps the error of a request that reached its deadline while
+// reporting ErrTimeout, so both can be checked with errors.Is.
+type timeoutError struct {
+	err error
+}
+
+func (e timeoutError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrTimeout, e.err)
+}
+
+func (e timeoutError) Unwrap() []error {
+	return []error{ErrTimeout, e.err}
+}
+
+// mapTransportError returns the errors of requests that reached their deadline, either
+// the Client Timeout or the context one, as ErrTimeout.
+func mapTransportError(err error) error {
+	if err == nil || errors.Is(err, ErrTimeout) {
 		return err
 	}
 
+	var timeout interface{ Timeout() bool }
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &timeout) && timeout.Timeout()) {
+		return timeoutError{err: err}
+	}
+
+	return err
+}
+
+// IsRetryable reports whether err is a transient failure that may succeed if the request
+// is made again later: timeouts, transport errors, 429 and 5xx responses, or an open
+// CircuitBreaker. Cancelled requests and other responses are not retryable.
+//
+// Whether retrying is safe depends on the request too, see DefaultRetryPolicy.
+func IsRetryable(err error) bool {
+	var netErr net.Error
+
+	switch {
+	case err == nil, errors.Is(err, context.Canceled):
+		return false
+	case errors.Is(err, ErrTimeout),
+		errors.Is(err, ErrTooManyRequests),
+		errors.Is(err, ErrServer),
+		errors.Is(err, ErrCircuitOpen),
+		errors.As(err, &netErr):
+		return true
+	default:
+		return false
+	}
+}
+
+// retryLimitError keeps the error of the last attempt while reporting the reached
+// limit, ErrRetryLimit or ErrRetryBudget, so both can be checked with errors.Is.
+type retryLimitError struct {
+	limit error
+	last  error
+}
+
+func (e retryLimitError) Error() string {
+	return e.limit.Error()
+}
+
+func (e retryLimitError) Unwrap() []error {
+	return []error{e.limit, e.last}
+}
+
+// ValidationError is the RequestError returned for a 400 response, keeping every line
+// of the validation failure list as a FieldError.
+//
+// It can be obtained as a RequestError too with errors.As, so the checks made before
+// it was introduced keep working.
+type ValidationError struct {
+	RequestError
+	fields []FieldError
+}
+
+// Fields returns the failures of the validation failure list in the order received.
+func (e ValidationError) Fields() []FieldError {
+	return append([]FieldError(nil), e.fields...)
+}
+
+// As sets the target to the embedded RequestError when it's a *RequestError.
+func (e ValidationError) As(target any) bool {
+	if requestErr, ok := target.(*RequestError); ok {
+		*requestErr = e.RequestError
+		return true
+	}
+
+	return false
+}
+
+var fieldErrorPattern = regexp.MustCompile(`^(\S+) in (?:body|query|path) (.+)$`)
+
+// parseFieldError splits a validation failure line like "name.0 in body should be at
+// most 140 chars long" into the field path, the rule inferred from the message and the message.
+func parseFieldError(line string) FieldError {
+	line = strings.TrimSpace(line)
+
+	match := fieldErrorPattern.FindStringSubmatch(line)
+	if match == nil {
+		return FieldError{Message: line}
+	}
+
+	var (
+		field, message = match[1], match[2]
+		rule           string
+	)
+
+	switch {
+	case strings.HasPrefix(message, "is required"):
+		rule = "required"
+	case strings.HasPrefix(message, "must be of type"):
+		rule = "type"
+	case strings.HasPrefix(message, "should match"):
+		rule = "pattern"
+	case strings.HasPrefix(message, "should be one of"):
+		rule = "enum"
+	case strings.Contains(message, "at most"):
+		rule = "max"
+	case strings.Contains(message, "at least"):
+		rule = "min"
+	}
+
+	return FieldError{Field: field, Rule: rule, Message: message}
+}
+
+const (
+	requestIDHeader = "X-Request-Id"
+	maxErrorBody    = 4 << 10
+)
+
+var redactedFields = regexp.MustCompile(
+	`"(name|alternative_names|account_number|iban|bank_id|bic|secondary_identification|user_defined_data)"` +
+		`\s*:\s*("(?:[^"\\]|\\.)*"|\[[^\]]*\])`)
+
+// redactBody replaces the values of the account attributes that identify its holder.
+func redactBody(body string) string {
+	return redactedFields.ReplaceAllString(body, `"$1":"[REDACTED]"`)
+}
+
+// handleVersionConflictError returns the RequestError of a 409 response to a request made
+// with the version of the resource, keeping the response details while matching
+// ErrVersionConflict with errors.Is.
+func handleVersionConflictError(req *http.Request, resp *http.Response) error {
+	err := handleResponseError(req, resp)
+
+	var requestErr RequestError
+	if !errors.As(err, &requestErr) {
+		// the body couldn't be decoded, but the status is enough to report the conflict.
+		requestErr = RequestError{
+			StatusCode:     resp.StatusCode,
+			RequestID:      resp.Header.Get(requestIDHeader),
+			Method:         req.Method,
+			Path:           req.URL.Path,
+			IdempotencyKey: req.Header.Get(idempotencyKeyHeader),
+		}
+	}
+
+	requestErr.Err = versionConflictError{}
+
+	return requestErr
+}
+
+func handleResponseError(req *http.Request, resp *http.Response) error {
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var respErr ResponseError
+	decodeErr := unmarshalBody(bytes.NewReader(content), &respErr)
+
+	if len(content) > maxErrorBody {
+		content = content[:maxErrorBody]
+	}
+
+	details := RequestError{
+		StatusCode:     resp.StatusCode,
+		RequestID:      resp.Header.Get(requestIDHeader),
+		Method:         req.Method,
+		Path:           req.URL.Path,
+		ErrorCode:      respErr.ErrorCode,
+		Body:           string(content),
+		IdempotencyKey: req.Header.Get(idempotencyKeyHeader),
+	}
+
+	if decodeErr != nil {
+		// the body isn't JSON, e.g. the HTML page of a proxy, so it's kept as the message.
+		details.Err = ErrUnmarshalInvalidValue
+		if message := strings.TrimSpace(string(content)); message != "" {
+			details.Err = fmt.Errorf("%w: %s", ErrUnmarshalInvalidValue, message)
+		}
+
+		return details
+	}
+
 	switch resp.StatusCode {
 	case http.StatusBadRequest:
 		var (
-			errors    = strings.Split(respErr.ErrorMessage, "\n")
+			lines     = strings.Split(respErr.ErrorMessage, "\n")
 			errBuffer bytes.Buffer
+			fields    []FieldError
 		)
 
-		for _, err := range errors {
-			if !strings.EqualFold(err, "validation failure list:") {
-				errBuffer.WriteString(fmt.Sprintf("%s;", err))
+		for _, line := range lines {
+			if !strings.EqualFold(line, "validation failure list:") {
+				errBuffer.WriteString(fmt.Sprintf("%s;", line))
+				fields = append(fields, parseFieldError(line))
 			}
 		}
 
-		respErr.ErrorMessage = errBuffer.String()
+		details.Err = errors.New(errBuffer.String())
+
+		return ValidationError{RequestError: details, fields: fields}
 	case http.StatusNotFound:
-		respErr.ErrorMessage = ErrRecordNotFound.Error()
+		details.Err = ErrRecordNotFound
+	default:
+		details.Err = errors.New(respErr.ErrorMessage)
 	}
 
-	return RequestError{Err: errors.New(respErr.ErrorMessage), StatusCode: resp.StatusCode}
+	return details
 }
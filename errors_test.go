@@ -0,0 +1,174 @@
+package form3client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestError_WhenStatusCode_ThenIsItsSentinel(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expErr     error
+		retryable  bool
+	}{
+		{http.StatusBadRequest, f3Client.ErrBadRequest, false},
+		{http.StatusUnauthorized, f3Client.ErrUnauthorized, false},
+		{http.StatusForbidden, f3Client.ErrForbidden, false},
+		{http.StatusNotFound, f3Client.ErrRecordNotFound, false},
+		{http.StatusConflict, f3Client.ErrConflict, false},
+		{http.StatusTooManyRequests, f3Client.ErrTooManyRequests, true},
+		{http.StatusInternalServerError, f3Client.ErrServer, true},
+		{http.StatusServiceUnavailable, f3Client.ErrServer, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.statusCode), func(t *testing.T) {
+			var (
+				doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+					return &http.Response{
+						StatusCode: tt.statusCode,
+						Body:       getReaderFromInterface(f3Client.ResponseError{ErrorMessage: "some error"}),
+					}, nil
+				}
+
+				c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+			)
+
+			_, err := c.Fetch(context.TODO(), uuid.NewString())
+
+			var requestErr f3Client.RequestError
+			assert.ErrorAs(t, err, &requestErr)
+			assert.Equal(t, tt.statusCode, requestErr.StatusCode)
+			assert.ErrorIs(t, err, tt.expErr)
+			assert.Equal(t, tt.retryable, f3Client.IsRetryable(err))
+		})
+	}
+}
+
+func TestRequestError_WhenVersionConflict_ThenIsErrConflict(t *testing.T) {
+	assert.ErrorIs(t, f3Client.ErrVersionConflict, f3Client.ErrConflict)
+	assert.ErrorIs(t, f3Client.ErrRequiredID, f3Client.ErrBadRequest)
+	assert.NotErrorIs(t, f3Client.ErrVersionConflict, f3Client.ErrBadRequest)
+}
+
+func TestDo_WhenDeadlineExceeded_ThenFailsWithErrTimeout(t *testing.T) {
+	var (
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return nil, fmt.Errorf("awaiting headers: %w", context.DeadlineExceeded)
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := c.Fetch(context.TODO(), uuid.NewString())
+
+	assert.ErrorIs(t, err, f3Client.ErrTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualError(t, err, f3Client.ErrTimeout.Error()+": awaiting headers: context deadline exceeded")
+	assert.True(t, f3Client.IsRetryable(err))
+}
+
+func TestIsRetryable_WhenNotRetryable_ThenFalse(t *testing.T) {
+	assert.False(t, f3Client.IsRetryable(nil))
+	assert.False(t, f3Client.IsRetryable(context.Canceled))
+	assert.False(t, f3Client.IsRetryable(errors.New("client_err")))
+	assert.False(t, f3Client.IsRetryable(f3Client.ErrVersionConflict))
+	assert.True(t, f3Client.IsRetryable(f3Client.ErrCircuitOpen))
+}
+
+func TestRequestError_WhenResponseError_ThenKeepsRequestDetails(t *testing.T) {
+	var (
+		testID = uuid.NewString()
+		body   = `{"error_message":"invalid account","error_code":"ACC-001",` +
+			`"data":{"attributes":{"name":["Samantha Holder"],"iban":"GB11NWBK40030041426819","country":"GB"}}}`
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusUnprocessableEntity,
+				Header:     http.Header{"X-Request-Id": []string{"req-123"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	err := c.DeleteVersion(f3Client.ContextWithIdempotencyKey(context.TODO(), "key-1"), testID, 3)
+
+	var requestErr f3Client.RequestError
+	assert.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, f3Client.RequestError{
+		StatusCode:     http.StatusUnprocessableEntity,
+		Err:            requestErr.Err,
+		RequestID:      "req-123",
+		Method:         http.MethodDelete,
+		Path:           "/v1/organisation/accounts/" + testID,
+		ErrorCode:      "ACC-001",
+		Body:           body,
+		IdempotencyKey: "key-1",
+	}, requestErr)
+
+	assert.Equal(t, "status:422, error:'invalid account'.", fmt.Sprintf("%v", err))
+	assert.Equal(t, "status:422, error:'invalid account'. method:DELETE, path:/v1/organisation/accounts/"+testID+
+		`, request_id:req-123, idempotency_key:key-1, error_code:ACC-001, body:{"error_message":"invalid account","error_code":"ACC-001",`+
+		`"data":{"attributes":{"name":"[REDACTED]","iban":"[REDACTED]","country":"GB"}}}`, fmt.Sprintf("%+v", err))
+}
+
+func TestRequestError_WhenLargeBody_ThenBodyIsTruncated(t *testing.T) {
+	var (
+		message = strings.Repeat("a", 8<<10)
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       getReaderFromInterface(f3Client.ResponseError{ErrorMessage: message}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := c.Fetch(context.TODO(), uuid.NewString())
+
+	var requestErr f3Client.RequestError
+	assert.ErrorAs(t, err, &requestErr)
+	assert.Len(t, requestErr.Body, 4<<10)
+	assert.EqualError(t, requestErr.Err, message)
+}
+
+func TestIdempotencyKeyFromError_WhenVersionConflict_ThenReturnsRequestKey(t *testing.T) {
+	var (
+		version int64 = 1
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusConflict,
+				Body:       getReaderFromInterface(f3Client.ResponseError{ErrorMessage: "invalid version"}),
+			}, nil
+		}
+
+		c   = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+		ctx = f3Client.ContextWithIdempotencyKey(context.Background(), "key-1")
+	)
+
+	_, updateErr := c.Update(ctx, uuid.NewString(), f3Client.AccountRequest{Version: &version})
+	deleteErr := c.DeleteVersion(ctx, uuid.NewString(), version)
+
+	for _, err := range []error{updateErr, deleteErr} {
+		assert.ErrorIs(t, err, f3Client.ErrVersionConflict)
+
+		key, ok := f3Client.IdempotencyKeyFromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, "key-1", key)
+	}
+}
@@ -0,0 +1,292 @@
+// Package iban parses and validates International Bank Account Numbers (ISO 13616)
+// and Business Identifier Codes (ISO 9362), and derives the national bank ID and
+// account number contained in an IBAN.
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidFormat signals that the value contains characters other than letters and digits
+	// or its country code and check digits are malformed.
+	ErrInvalidFormat = errors.New("invalid IBAN format")
+
+	// ErrInvalidLength signals that the IBAN length doesn't match the length of its country,
+	// or exceeds the 34 characters of an IBAN when its country is not in the registry.
+	ErrInvalidLength = errors.New("invalid IBAN length")
+
+	// ErrInvalidBBAN signals that the Basic Bank Account Number doesn't match the structure
+	// of its country.
+	ErrInvalidBBAN = errors.New("invalid IBAN BBAN structure")
+
+	// ErrInvalidChecksum signals that the IBAN check digits don't pass the mod-97 check.
+	ErrInvalidChecksum = errors.New("invalid IBAN checksum")
+
+	// ErrInvalidBIC signals that the value is not an 8 or 11 characters BIC.
+	ErrInvalidBIC = errors.New("invalid BIC")
+)
+
+// IBAN is a parsed and validated International Bank Account Number.
+type IBAN struct {
+	CountryCode string
+	CheckDigits string
+	BBAN        string
+}
+
+// String returns the IBAN in its electronic format, without spaces.
+func (i IBAN) String() string {
+	return i.CountryCode + i.CheckDigits + i.BBAN
+}
+
+// Print returns the IBAN in its print format, in groups of four characters.
+func (i IBAN) Print() string {
+	var (
+		value  = i.String()
+		groups = make([]string, 0, len(value)/4+1)
+	)
+
+	for len(value) > 4 {
+		groups, value = append(groups, value[:4]), value[4:]
+	}
+
+	return strings.Join(append(groups, value), " ")
+}
+
+// BankID returns the national bank identifier contained in the BBAN, i.e. the sort code
+// of a GB IBAN. It's empty when the position of the bank ID is not known for the country.
+func (i IBAN) BankID() string {
+	return registry[i.CountryCode].bankID.slice(i.BBAN)
+}
+
+// AccountNumber returns the national account number contained in the BBAN. It's empty
+// when the position of the account number is not known for the country.
+func (i IBAN) AccountNumber() string {
+	return registry[i.CountryCode].account.slice(i.BBAN)
+}
+
+// Normalize removes the spaces of an IBAN in print format and converts it to upper case.
+func Normalize(value string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(value), ""))
+}
+
+// Parse normalizes and validates an IBAN, checking its length and BBAN structure for
+// its country and its mod-97 check digits. The IBANs of countries missing from the
+// registry are only checked against the maximum length and the check digits.
+func Parse(value string) (IBAN, error) {
+	value = Normalize(value)
+
+	if len(value) < 5 || !ibanPattern.MatchString(value) {
+		return IBAN{}, fmt.Errorf("%w: %q", ErrInvalidFormat, value)
+	}
+
+	iban := IBAN{CountryCode: value[:2], CheckDigits: value[2:4], BBAN: value[4:]}
+
+	if spec, ok := registry[iban.CountryCode]; ok {
+		if len(value) != spec.length {
+			return IBAN{}, fmt.Errorf("%w: %d, must be %d for country %s", ErrInvalidLength, len(value), spec.length, iban.CountryCode)
+		}
+
+		if !spec.bban.MatchString(iban.BBAN) {
+			return IBAN{}, fmt.Errorf("%w: %q for country %s", ErrInvalidBBAN, iban.BBAN, iban.CountryCode)
+		}
+	} else if len(value) > maxLength {
+		return IBAN{}, fmt.Errorf("%w: %d, must be at most %d", ErrInvalidLength, len(value), maxLength)
+	}
+
+	if mod97(iban.BBAN+iban.CountryCode+iban.CheckDigits) != 1 {
+		return IBAN{}, fmt.Errorf("%w: %q", ErrInvalidChecksum, value)
+	}
+
+	return iban, nil
+}
+
+// Validate reports whether the value is a valid IBAN, see Parse.
+func Validate(value string) error {
+	_, err := Parse(value)
+	return err
+}
+
+// ValidateBIC reports whether the value is a BIC of 8 or 11 characters, made of the
+// institution, country, location and optional branch codes.
+func ValidateBIC(value string) error {
+	if !bicPattern.MatchString(value) {
+		return fmt.Errorf("%w: %q", ErrInvalidBIC, value)
+	}
+
+	return nil
+}
+
+// maxLength is the longest IBAN allowed by ISO 13616.
+const maxLength = 34
+
+var (
+	ibanPattern = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]+$`)
+	bicPattern  = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+)
+
+// mod97 computes the ISO 7064 MOD 97-10 remainder of the value, converting each
+// letter to two digits (A=10 .. Z=35).
+func mod97(value string) int {
+	remainder := 0
+	for _, r := range value {
+		if r >= 'A' && r <= 'Z' {
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		} else {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		}
+	}
+
+	return remainder
+}
+
+// span is the position of a national field inside the BBAN, a zero span is unknown,
+// as it is for the countries missing from the registry.
+type span struct {
+	start, end int
+}
+
+func (s span) slice(bban string) string {
+	if s.end == 0 || s.end > len(bban) {
+		return ""
+	}
+
+	return bban[s.start:s.end]
+}
+
+type countrySpec struct {
+	length  int
+	bban    *regexp.Regexp
+	bankID  span
+	account span
+}
+
+// newCountrySpec builds the spec of a country from the BBAN structure used by the
+// SWIFT IBAN registry, i.e. "4!a6!n8!n": fixed length groups of (n) digits, (a) upper
+// case letters or (c) letters and digits.
+func newCountrySpec(structure string, bankID, account span) countrySpec {
+	var (
+		pattern strings.Builder
+		length  = 4
+		groups  = regexp.MustCompile(`([0-9]+)!([nac])`).FindAllStringSubmatch(structure, -1)
+	)
+
+	pattern.WriteString("^")
+	for _, group := range groups {
+		size, _ := strconv.Atoi(group[1])
+		length += size
+
+		switch group[2] {
+		case "n":
+			pattern.WriteString(fmt.Sprintf("[0-9]{%d}", size))
+		case "a":
+			pattern.WriteString(fmt.Sprintf("[A-Z]{%d}", size))
+		case "c":
+			pattern.WriteString(fmt.Sprintf("[A-Z0-9]{%d}", size))
+		}
+	}
+	pattern.WriteString("$")
+
+	return countrySpec{
+		length:  length,
+		bban:    regexp.MustCompile(pattern.String()),
+		bankID:  bankID,
+		account: account,
+	}
+}
+
+var registry = map[string]countrySpec{
+	"AD": newCountrySpec("4!n4!n12!c", span{0, 4}, span{8, 20}),
+	"AE": newCountrySpec("3!n16!n", span{0, 3}, span{3, 19}),
+	"AL": newCountrySpec("8!n16!c", span{0, 7}, span{8, 24}),
+	"AT": newCountrySpec("5!n11!n", span{0, 5}, span{5, 16}),
+	"AZ": newCountrySpec("4!a20!c", span{0, 4}, span{4, 24}),
+	"BA": newCountrySpec("3!n3!n8!n2!n", span{0, 3}, span{6, 14}),
+	"BE": newCountrySpec("3!n7!n2!n", span{0, 3}, span{3, 10}),
+	"BG": newCountrySpec("4!a4!n2!n8!c", span{0, 4}, span{10, 18}),
+	"BH": newCountrySpec("4!a14!c", span{0, 4}, span{4, 18}),
+	"BI": newCountrySpec("5!n5!n11!n2!n", span{0, 5}, span{10, 21}),
+	"BR": newCountrySpec("8!n5!n10!n1!a1!c", span{0, 8}, span{13, 23}),
+	"BY": newCountrySpec("4!c4!n16!c", span{0, 4}, span{8, 24}),
+	"CH": newCountrySpec("5!n12!c", span{0, 5}, span{5, 17}),
+	"CR": newCountrySpec("4!n14!n", span{0, 4}, span{4, 18}),
+	"CY": newCountrySpec("3!n5!n16!c", span{0, 3}, span{8, 24}),
+	"CZ": newCountrySpec("4!n6!n10!n", span{0, 4}, span{4, 20}),
+	"DE": newCountrySpec("8!n10!n", span{0, 8}, span{8, 18}),
+	"DJ": newCountrySpec("5!n5!n11!n2!n", span{0, 5}, span{10, 21}),
+	"DK": newCountrySpec("4!n9!n1!n", span{0, 4}, span{4, 14}),
+	"DO": newCountrySpec("4!c20!n", span{0, 4}, span{4, 24}),
+	"EE": newCountrySpec("2!n2!n11!n1!n", span{0, 2}, span{4, 16}),
+	"EG": newCountrySpec("4!n4!n17!n", span{0, 4}, span{8, 25}),
+	"ES": newCountrySpec("4!n4!n1!n1!n10!n", span{0, 8}, span{10, 20}),
+	"FI": newCountrySpec("3!n11!n", span{0, 3}, span{3, 14}),
+	"FK": newCountrySpec("2!a12!n", span{0, 2}, span{2, 14}),
+	"FO": newCountrySpec("4!n9!n1!n", span{0, 4}, span{4, 14}),
+	"FR": newCountrySpec("5!n5!n11!c2!n", span{0, 10}, span{10, 21}),
+	"GB": newCountrySpec("4!a6!n8!n", span{4, 10}, span{10, 18}),
+	"GE": newCountrySpec("2!a16!n", span{0, 2}, span{2, 18}),
+	"GI": newCountrySpec("4!a15!c", span{0, 4}, span{4, 19}),
+	"GL": newCountrySpec("4!n9!n1!n", span{0, 4}, span{4, 14}),
+	"GR": newCountrySpec("3!n4!n16!c", span{0, 7}, span{7, 23}),
+	"GT": newCountrySpec("4!c20!c", span{0, 4}, span{4, 24}),
+	"HN": newCountrySpec("4!a20!n", span{0, 4}, span{4, 24}),
+	"HR": newCountrySpec("7!n10!n", span{0, 7}, span{7, 17}),
+	"HU": newCountrySpec("3!n4!n1!n15!n1!n", span{0, 7}, span{8, 23}),
+	"IE": newCountrySpec("4!a6!n8!n", span{4, 10}, span{10, 18}),
+	"IL": newCountrySpec("3!n3!n13!n", span{0, 3}, span{6, 19}),
+	"IQ": newCountrySpec("4!a3!n12!n", span{0, 4}, span{7, 19}),
+	"IS": newCountrySpec("4!n2!n6!n10!n", span{0, 4}, span{6, 12}),
+	"IT": newCountrySpec("1!a5!n5!n12!c", span{1, 11}, span{11, 23}),
+	"JO": newCountrySpec("4!a4!n18!c", span{0, 4}, span{8, 26}),
+	"KW": newCountrySpec("4!a22!c", span{0, 4}, span{4, 26}),
+	"KZ": newCountrySpec("3!n13!c", span{0, 3}, span{3, 16}),
+	"LB": newCountrySpec("4!n20!c", span{0, 4}, span{4, 24}),
+	"LC": newCountrySpec("4!a24!c", span{0, 4}, span{4, 28}),
+	"LI": newCountrySpec("5!n12!c", span{0, 5}, span{5, 17}),
+	"LT": newCountrySpec("5!n11!n", span{0, 5}, span{5, 16}),
+	"LU": newCountrySpec("3!n13!c", span{0, 3}, span{3, 16}),
+	"LV": newCountrySpec("4!a13!c", span{0, 4}, span{4, 17}),
+	"LY": newCountrySpec("3!n3!n15!n", span{0, 3}, span{6, 21}),
+	"MC": newCountrySpec("5!n5!n11!c2!n", span{0, 10}, span{10, 21}),
+	"MD": newCountrySpec("2!c18!c", span{0, 2}, span{2, 20}),
+	"ME": newCountrySpec("3!n13!n2!n", span{0, 3}, span{3, 16}),
+	"MK": newCountrySpec("3!n10!c2!n", span{0, 3}, span{3, 13}),
+	"MN": newCountrySpec("4!n12!n", span{0, 4}, span{4, 16}),
+	"MR": newCountrySpec("5!n5!n11!n2!n", span{0, 5}, span{10, 21}),
+	"MT": newCountrySpec("4!a5!n18!c", span{0, 4}, span{9, 27}),
+	"MU": newCountrySpec("4!a2!n2!n12!n3!n3!a", span{0, 6}, span{8, 20}),
+	"NI": newCountrySpec("4!a20!n", span{0, 4}, span{4, 24}),
+	"NL": newCountrySpec("4!a10!n", span{0, 4}, span{4, 14}),
+	"NO": newCountrySpec("4!n6!n1!n", span{0, 4}, span{4, 10}),
+	"OM": newCountrySpec("3!n16!c", span{0, 3}, span{3, 19}),
+	"PK": newCountrySpec("4!a16!c", span{0, 4}, span{4, 20}),
+	"PL": newCountrySpec("8!n16!n", span{0, 8}, span{8, 24}),
+	"PS": newCountrySpec("4!a21!c", span{0, 4}, span{4, 25}),
+	"PT": newCountrySpec("4!n4!n11!n2!n", span{0, 8}, span{8, 19}),
+	"QA": newCountrySpec("4!a21!c", span{0, 4}, span{4, 25}),
+	"RO": newCountrySpec("4!a16!c", span{0, 4}, span{4, 20}),
+	"RS": newCountrySpec("3!n13!n2!n", span{0, 3}, span{3, 16}),
+	"RU": newCountrySpec("9!n5!n15!c", span{0, 9}, span{14, 29}),
+	"SA": newCountrySpec("2!n18!c", span{0, 2}, span{2, 20}),
+	"SC": newCountrySpec("4!a2!n2!n16!n3!a", span{0, 6}, span{8, 24}),
+	"SD": newCountrySpec("2!n12!n", span{0, 2}, span{2, 14}),
+	"SE": newCountrySpec("3!n16!n1!n", span{0, 3}, span{3, 19}),
+	"SI": newCountrySpec("5!n8!n2!n", span{0, 5}, span{5, 13}),
+	"SK": newCountrySpec("4!n6!n10!n", span{0, 4}, span{4, 20}),
+	"SM": newCountrySpec("1!a5!n5!n12!c", span{1, 11}, span{11, 23}),
+	"SO": newCountrySpec("4!n3!n12!n", span{0, 4}, span{7, 19}),
+	"ST": newCountrySpec("4!n4!n11!n2!n", span{0, 4}, span{8, 19}),
+	"SV": newCountrySpec("4!a20!n", span{0, 4}, span{4, 24}),
+	"TL": newCountrySpec("3!n14!n2!n", span{0, 3}, span{3, 17}),
+	"TN": newCountrySpec("2!n3!n13!n2!n", span{0, 2}, span{5, 18}),
+	"TR": newCountrySpec("5!n1!n16!c", span{0, 5}, span{6, 22}),
+	"UA": newCountrySpec("6!n19!c", span{0, 6}, span{6, 25}),
+	"VA": newCountrySpec("3!n15!n", span{0, 3}, span{3, 18}),
+	"VG": newCountrySpec("4!a16!n", span{0, 4}, span{4, 20}),
+	"XK": newCountrySpec("4!n10!n2!n", span{0, 4}, span{4, 14}),
+	"YE": newCountrySpec("4!a4!n18!c", span{0, 4}, span{8, 26}),
+}
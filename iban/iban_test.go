@@ -0,0 +1,85 @@
+package iban_test
+
+import (
+	"testing"
+
+	"form3-client-library/iban"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_WhenValidIBAN_ThenReturnsParts(t *testing.T) {
+	tests := []struct {
+		value         string
+		bankID        string
+		accountNumber string
+	}{
+		{"GB82 WEST 1234 5698 7654 32", "123456", "98765432"},
+		{"de89370400440532013000", "37040044", "0532013000"},
+		{"FR1420041010050500013M02606", "2004101005", "0500013M026"},
+		{"ES9121000418450200051332", "21000418", "0200051332"},
+		{"IT60X0542811101000000123456", "0542811101", "000000123456"},
+		{"NL91ABNA0417164300", "ABNA", "0417164300"},
+		{"BE68539007547034", "539", "0075470"},
+		{"CH9300762011623852957", "00762", "011623852957"},
+		{"PL61109010140000071219812874", "10901014", "0000071219812874"},
+		{"PT50000201231234567890154", "00020123", "12345678901"},
+		{"LU280019400644750000", "001", "9400644750000"},
+		{"GR1601101250000000012300695", "0110125", "0000000012300695"},
+		{"BR1800360305000010009795493C1", "00360305", "0009795493"},
+		{"UA213223130000026007233566001", "322313", "0000026007233566001"},
+		{"SC18SSCB11010000000000001497USD", "SSCB11", "0000000000001497"},
+		{"RU0304452522540817810538091310419", "044525225", "810538091310419"},
+		{"XX57WEST12345698765432", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			parsed, err := iban.Parse(tt.value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, iban.Normalize(tt.value), parsed.String())
+			assert.Equal(t, tt.bankID, parsed.BankID())
+			assert.Equal(t, tt.accountNumber, parsed.AccountNumber())
+		})
+	}
+}
+
+func TestParse_WhenInvalidIBAN_ThenReturnsErr(t *testing.T) {
+	tests := []struct {
+		value  string
+		expErr error
+	}{
+		{"", iban.ErrInvalidFormat},
+		{"GB82-WEST-1234", iban.ErrInvalidFormat},
+		{"XX82WEST12345698765432", iban.ErrInvalidChecksum},
+		{"XX57WEST123456987654321234567890123", iban.ErrInvalidLength},
+		{"BR1800360305000010009795493C", iban.ErrInvalidLength},
+		{"GB82WEST123456987654", iban.ErrInvalidLength},
+		{"GB821234123456987654AB", iban.ErrInvalidBBAN},
+		{"GB83WEST12345698765432", iban.ErrInvalidChecksum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			_, err := iban.Parse(tt.value)
+			assert.ErrorIs(t, err, tt.expErr)
+			assert.ErrorIs(t, iban.Validate(tt.value), tt.expErr)
+		})
+	}
+}
+
+func TestPrint_WhenParsed_ThenGroupsOfFour(t *testing.T) {
+	parsed, err := iban.Parse("GB82WEST12345698765432")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "GB82 WEST 1234 5698 7654 32", parsed.Print())
+}
+
+func TestValidateBIC(t *testing.T) {
+	assert.NoError(t, iban.ValidateBIC("NWBKGB22"))
+	assert.NoError(t, iban.ValidateBIC("DEUTDEFF500"))
+	assert.ErrorIs(t, iban.ValidateBIC("NWBKGB2"), iban.ErrInvalidBIC)
+	assert.ErrorIs(t, iban.ValidateBIC("nwbkgb22"), iban.ErrInvalidBIC)
+	assert.ErrorIs(t, iban.ValidateBIC("NWBK1B22"), iban.ErrInvalidBIC)
+}
@@ -0,0 +1,94 @@
+package form3client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+type idempotencyKeyCtxKey struct{}
+
+// ContextWithIdempotencyKey returns a copy of ctx carrying the Idempotency-Key sent by the
+// mutating requests (POST, PATCH and DELETE) made with it, so a failed call can be replayed
+// with the same key and the API processes it only once.
+//
+// A key identifies a single mutation, so the returned ctx must be used for one mutating call
+// only, e.g. a Create followed by a Delete with the same ctx makes the API reject or replay
+// the Delete. Modify derives a key per attempt by appending the attempt number to it.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// withAttemptIdempotencyKey returns ctx with its Idempotency-Key suffixed by the attempt,
+// so every attempt of a call sending a different request has its own key while a replay
+// of the call sends the same keys. The first attempt keeps the key unchanged.
+func withAttemptIdempotencyKey(ctx context.Context, attempt uint) context.Context {
+	key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	if !ok || key == "" || attempt <= 1 {
+		return ctx
+	}
+
+	return ContextWithIdempotencyKey(ctx, fmt.Sprintf("%s-%d", key, attempt))
+}
+
+// IdempotencyKeyFromError returns the Idempotency-Key of the request that failed with err,
+// to replay it with ContextWithIdempotencyKey, including the version conflicts of Update and
+// DeleteVersion. It reports false if the request had no key.
+func IdempotencyKeyFromError(err error) (string, bool) {
+	var keyErr idempotencyKeyError
+	if errors.As(err, &keyErr) {
+		return keyErr.key, true
+	}
+
+	var requestErr RequestError
+	if errors.As(err, &requestErr) && requestErr.IdempotencyKey != "" {
+		return requestErr.IdempotencyKey, true
+	}
+
+	return "", false
+}
+
+// idempotencyKeyError keeps the Idempotency-Key of a request that failed without response.
+type idempotencyKeyError struct {
+	key string
+	err error
+}
+
+func (e idempotencyKeyError) Error() string {
+	return e.err.Error()
+}
+
+func (e idempotencyKeyError) Unwrap() error {
+	return e.err
+}
+
+// idempotencyKey returns the key of a mutating request, the one of the call, the one of
+// the ctx or a random one when the ClientOption IdempotencyKeys is set, empty otherwise.
+func (c *Client) idempotencyKey(ctx context.Context, method string) string {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodDelete:
+	default:
+		return ""
+	}
+
+	if c.callIdempotencyKey != "" {
+		return c.callIdempotencyKey
+	}
+
+	if key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string); ok && key != "" {
+		return key
+	}
+
+	if c.idempotencyKeys {
+		return uuid.NewString()
+	}
+
+	return ""
+}
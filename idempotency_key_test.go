@@ -0,0 +1,132 @@
+package form3client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKeys_WhenCreateRetried_ThenSendsSameKeyOnEveryAttempt(t *testing.T) {
+	var (
+		keys  []string
+		clock = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if r.Method == http.MethodPost && len(keys) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"id":"test-id"}}`))
+	}))
+	defer server.Close()
+
+	c := f3Client.NewClient(
+		f3Client.BaseURL(server.URL),
+		f3Client.RetriesWithPolicy(3, f3Client.ConstantBackoff(time.Second), f3Client.WithRetryClock(clock)),
+		f3Client.IdempotencyKeys(),
+	)
+
+	account, err := c.Create(context.Background(), newGBAccountRequest())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test-id", account.ID)
+	assert.Len(t, keys, 3)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, []string{keys[0], keys[0], keys[0]}, keys)
+
+	_, err = c.Create(context.Background(), newGBAccountRequest())
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, keys[0], keys[3])
+}
+
+func TestIdempotencyKey_WhenProvidedByContext_ThenSentOnlyOnMutatingRequests(t *testing.T) {
+	var (
+		keys = map[string]string{}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			keys[req.Method] = req.Header.Get("Idempotency-Key")
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+
+		c   = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+		ctx = f3Client.ContextWithIdempotencyKey(context.Background(), "key-1")
+	)
+
+	_, _ = c.Fetch(ctx, uuid.NewString())
+	_ = c.Delete(ctx, uuid.NewString())
+
+	assert.Equal(t, map[string]string{http.MethodGet: "", http.MethodDelete: "key-1"}, keys)
+}
+
+func TestIdempotencyKey_WhenTransportErr_ThenErrKeepsKey(t *testing.T) {
+	var (
+		expErr = errors.New("client_err")
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return nil, expErr
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc), f3Client.IdempotencyKeys())
+	)
+
+	_, err := c.Create(context.Background(), newGBAccountRequest())
+
+	key, ok := f3Client.IdempotencyKeyFromError(err)
+	assert.True(t, ok)
+	assert.NotEmpty(t, key)
+	assert.ErrorIs(t, err, expErr)
+	assert.EqualError(t, err, expErr.Error())
+
+	_, err = c.Fetch(context.Background(), uuid.NewString())
+
+	_, ok = f3Client.IdempotencyKeyFromError(err)
+	assert.False(t, ok)
+}
+
+func TestIdempotencyKey_WhenModifyRetried_ThenSendsKeyPerAttempt(t *testing.T) {
+	var (
+		keys []string
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			if req.Method == http.MethodGet {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       getReaderFromInterface(f3Client.AccountResponse{}),
+				}, nil
+			}
+
+			keys = append(keys, req.Header.Get("Idempotency-Key"))
+			if len(keys) < 3 {
+				return &http.Response{StatusCode: http.StatusConflict, Body: http.NoBody}, nil
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       getReaderFromInterface(f3Client.AccountResponse{}),
+			}, nil
+		}
+
+		c   = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+		ctx = f3Client.ContextWithIdempotencyKey(context.Background(), "key-1")
+	)
+
+	_, err := c.Modify(ctx, uuid.NewString(), 3, func(account f3Client.Account) (f3Client.AccountRequest, error) {
+		return f3Client.AccountRequest{}, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key-1", "key-1-2", "key-1-3"}, keys)
+}
@@ -0,0 +1,109 @@
+package form3client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AccountMismatchError is returned by CreateIdempotent when an account with the requested
+// ID already exists but it doesn't match the requested one, so it was not created by
+// the request. It matches ErrAccountMismatch and ErrConflict with errors.Is.
+type AccountMismatchError struct {
+	// Account is the existing account.
+	Account Account
+	// Fields are the JSON names of the requested fields that don't match the existing account.
+	Fields []string
+}
+
+func (e AccountMismatchError) Error() string {
+	return fmt.Sprintf("%s: account %s differs in %s", ErrAccountMismatch, e.Account.ID, strings.Join(e.Fields, ", "))
+}
+
+func (e AccountMismatchError) Unwrap() []error {
+	return []error{ErrAccountMismatch, ErrConflict}
+}
+
+// CreateIdempotent allows to register a new account resource that can be safely retried:
+//
+// ctx (context.Context) context carries a deadline, a cancellation signal, and other values across API boundaries.
+//
+// account  (AccountRequest) account contains the basic and optional data for the register of a new account.
+//
+// It behaves as Create, but when the account ID already exists (409) or the outcome of the
+// request is unknown, i.e. a timeout, the account is fetched by its ID and returned if
+// every requested field matches it, as it was created by a previous attempt.
+//
+// If the existing account doesn't match AccountMismatchError is returned, while the original
+// error is returned if the account doesn't exist or can't be fetched.
+func (c *Client) CreateIdempotent(ctx context.Context, account AccountRequest) (Account, error) {
+	created, err := c.Create(ctx, account)
+	if err == nil || !(errors.Is(err, ErrConflict) || isAmbiguous(err)) || containsOnlyBlanks(account.ID) {
+		return created, err
+	}
+
+	existing, fetchErr := c.Fetch(ctx, account.ID)
+	if fetchErr != nil {
+		return Account{}, err
+	}
+
+	if fields := diffAccount(account, existing); len(fields) > 0 {
+		return Account{}, AccountMismatchError{Account: existing, Fields: fields}
+	}
+
+	return existing, nil
+}
+
+// isAmbiguous reports whether the request may have been processed by the API even though
+// it failed, so its outcome is unknown.
+func isAmbiguous(err error) bool {
+	var requestErr RequestError
+	if errors.As(err, &requestErr) {
+		return errors.Is(err, ErrServer)
+	}
+
+	return !errors.Is(err, context.Canceled) && IsRetryable(err)
+}
+
+// diffAccount returns the JSON names of the fields set in the request that don't
+// match the account, fields not set are ignored as the API may fill them.
+func diffAccount(req AccountRequest, account Account) []string {
+	var fields []string
+
+	if req.OrganisationID != account.OrganisationID {
+		fields = append(fields, "organisation_id")
+	}
+
+	if req.Type != "" && req.Type != account.Type {
+		fields = append(fields, "type")
+	}
+
+	if req.Attributes == nil {
+		return fields
+	}
+
+	var (
+		reqAttributes = reflect.ValueOf(*req.Attributes)
+		attributes    = reflect.ValueOf(account.AccountAttributes)
+	)
+
+	for i := 0; i < reqAttributes.NumField(); i++ {
+		var (
+			field = reqAttributes.Type().Field(i)
+			want  = reqAttributes.Field(i)
+			got   = attributes.FieldByName(field.Name)
+		)
+
+		if want.IsZero() || !got.IsValid() {
+			continue
+		}
+
+		if !reflect.DeepEqual(want.Interface(), got.Interface()) {
+			fields = append(fields, "attributes."+strings.Split(field.Tag.Get("json"), ",")[0])
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,127 @@
+package form3client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIdempotentTestAccount(req f3Client.AccountRequest) f3Client.Account {
+	status := f3Client.AccountStatusConfirmed
+
+	return f3Client.Account{
+		ID:             req.ID,
+		OrganisationID: req.OrganisationID,
+		Type:           req.Type,
+		AccountAttributes: f3Client.AccountAttributes{
+			AccountNumber: req.Attributes.AccountNumber,
+			BankID:        req.Attributes.BankID,
+			BankIDCode:    req.Attributes.BankIDCode,
+			Bic:           req.Attributes.Bic,
+			Country:       req.Attributes.Country,
+			Iban:          "GB11NWBK40030041426819",
+			Name:          req.Attributes.Name,
+			Status:        &status,
+		},
+	}
+}
+
+func TestCreateIdempotent_WhenConflictWithSameAccount_ThenReturnsExisting(t *testing.T) {
+	var (
+		req      = newGBAccountRequest()
+		existing = newIdempotentTestAccount(req)
+		methods  []string
+
+		doerMockFunc = func(client http.Client, r *http.Request) (resp *http.Response, err error) {
+			methods = append(methods, r.Method)
+			if r.Method == http.MethodPost {
+				return &http.Response{
+					StatusCode: http.StatusConflict,
+					Body:       getReaderFromInterface(f3Client.ResponseError{ErrorMessage: "Account cannot be created as it violates a duplicate constraint"}),
+				}, nil
+			}
+
+			return &http.Response{StatusCode: http.StatusOK, Body: getReaderFromInterface(f3Client.AccountResponse{Account: existing})}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	account, err := c.CreateIdempotent(context.TODO(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, existing, account)
+	assert.Equal(t, []string{http.MethodPost, http.MethodGet}, methods)
+}
+
+func TestCreateIdempotent_WhenTimeoutAndDifferentAccount_ThenFailsWithMismatch(t *testing.T) {
+	var (
+		req      = newGBAccountRequest()
+		existing = newIdempotentTestAccount(req)
+
+		doerMockFunc = func(client http.Client, r *http.Request) (resp *http.Response, err error) {
+			if r.Method == http.MethodPost {
+				return nil, context.DeadlineExceeded
+			}
+
+			return &http.Response{StatusCode: http.StatusOK, Body: getReaderFromInterface(f3Client.AccountResponse{Account: existing})}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	existing.AccountAttributes.AccountNumber = "00000000"
+	existing.AccountAttributes.Name = []string{"Another Holder"}
+
+	account, err := c.CreateIdempotent(context.TODO(), req)
+
+	var mismatchErr f3Client.AccountMismatchError
+	assert.ErrorAs(t, err, &mismatchErr)
+	assert.ErrorIs(t, err, f3Client.ErrAccountMismatch)
+	assert.ErrorIs(t, err, f3Client.ErrConflict)
+	assert.Equal(t, []string{"attributes.account_number", "attributes.name"}, mismatchErr.Fields)
+	assert.Equal(t, existing, mismatchErr.Account)
+	assert.Equal(t, f3Client.Account{}, account)
+}
+
+func TestCreateIdempotent_WhenTimeoutAndNotCreated_ThenFailsWithOriginalErr(t *testing.T) {
+	var (
+		doerMockFunc = func(client http.Client, r *http.Request) (resp *http.Response, err error) {
+			if r.Method == http.MethodPost {
+				return nil, context.DeadlineExceeded
+			}
+
+			return &http.Response{StatusCode: http.StatusNotFound, Body: getReaderFromInterface(f3Client.ResponseError{})}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := c.CreateIdempotent(context.TODO(), newGBAccountRequest())
+
+	assert.ErrorIs(t, err, f3Client.ErrTimeout)
+	assert.NotErrorIs(t, err, f3Client.ErrRecordNotFound)
+}
+
+func TestCreateIdempotent_WhenBadRequest_ThenDoesNotFetch(t *testing.T) {
+	var (
+		calls int
+
+		doerMockFunc = func(client http.Client, r *http.Request) (resp *http.Response, err error) {
+			calls++
+			return &http.Response{StatusCode: http.StatusBadRequest, Body: getReaderFromInterface(f3Client.ResponseError{ErrorMessage: "id in body is required"})}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	_, err := c.CreateIdempotent(context.TODO(), f3Client.AccountRequest{ID: uuid.NewString()})
+
+	assert.ErrorIs(t, err, f3Client.ErrBadRequest)
+	assert.Equal(t, 1, calls)
+}
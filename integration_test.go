@@ -37,7 +37,7 @@ func TestIntegrFetchTimeout_WhenDeadlineReached_ThenReturnTimeoutErr(t *testing.
 	account, err := client.Fetch(context.Background(), id)
 
 	assert.Empty(t, account)
-	assert.ErrorContains(t, err, "context deadline exceeded (Client.Timeout exceeded while awaiting headers)")
+	assert.ErrorIs(t, err, f3Client.ErrTimeout)
 }
 
 func TestIntegrFetch_WhenInvalidUUID_ThenReturnBadRequest(t *testing.T) {
@@ -54,7 +54,10 @@ func TestIntegrFetch_WhenInvalidUUID_ThenReturnBadRequest(t *testing.T) {
 
 	assert.Empty(t, account)
 	assert.Error(t, err)
-	assert.EqualValues(t, err, expErr)
+	var requestErr f3Client.RequestError
+	assert.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, expErr.StatusCode, requestErr.StatusCode)
+	assert.EqualError(t, err, expErr.Error())
 }
 
 func TestIntegrFetch_WhenResourceNotFound_ThenReturnBadRequest(t *testing.T) {
@@ -71,7 +74,7 @@ func TestIntegrFetch_WhenResourceNotFound_ThenReturnBadRequest(t *testing.T) {
 
 	assert.Empty(t, account)
 	assert.Error(t, err)
-	assert.EqualValues(t, err, expErr)
+	assert.EqualError(t, err, expErr.Error())
 }
 
 func TestIntegrFetch_WhenClientErr_ThenReturnGenericErr(t *testing.T) {
@@ -108,7 +111,7 @@ func TestIntegrDeleteTimeout_WhenDeadlineReached_ThenReturnTimeoutErr(t *testing
 	account, err := client.Fetch(context.Background(), id)
 
 	assert.Empty(t, account)
-	assert.EqualError(t, err, f3Client.ErrTimeout.Error())
+	assert.ErrorIs(t, err, f3Client.ErrTimeout)
 }
 
 func TestIntegrDelete_WhenInvalidUUID_ThenReturnBadRequest(t *testing.T) {
@@ -123,7 +126,10 @@ func TestIntegrDelete_WhenInvalidUUID_ThenReturnBadRequest(t *testing.T) {
 
 	err := client.Delete(context.Background(), "invalid_uuid")
 
-	assert.EqualValues(t, err, expErr)
+	var requestErr f3Client.RequestError
+	assert.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, expErr.StatusCode, requestErr.StatusCode)
+	assert.EqualError(t, err, expErr.Error())
 }
 
 func TestIntegrDelete_WhenResourceNotFound_ThenReturnBadRequest(t *testing.T) {
@@ -138,7 +144,7 @@ func TestIntegrDelete_WhenResourceNotFound_ThenReturnBadRequest(t *testing.T) {
 
 	err := client.Delete(context.Background(), uuid.NewString())
 
-	assert.EqualValues(t, err, expErr)
+	assert.EqualError(t, err, expErr.Error())
 }
 
 func TestIntegrDelete_WhenClientErr_ThenReturnGenericErr(t *testing.T) {
@@ -178,7 +184,10 @@ func TestIntegrCreate_WhenInvalidRequest_ThenErrWithBadRequets(t *testing.T) {
 	account, err := client.Create(context.Background(), req)
 
 	assert.Empty(t, account)
-	assert.EqualValues(t, err, expErr)
+	var requestErr f3Client.RequestError
+	assert.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, expErr.StatusCode, requestErr.StatusCode)
+	assert.EqualError(t, err, expErr.Error())
 }
 
 func TestIntegrCreateTimeout_WhenDeadlineReached_ThenReturnTimeoutErr(t *testing.T) {
@@ -187,7 +196,7 @@ func TestIntegrCreateTimeout_WhenDeadlineReached_ThenReturnTimeoutErr(t *testing
 	account, err := client.Create(context.Background(), f3Client.AccountRequest{})
 
 	assert.Empty(t, account)
-	assert.EqualError(t, err, f3Client.ErrTimeout.Error())
+	assert.ErrorIs(t, err, f3Client.ErrTimeout)
 }
 
 func TestIntegrCreate_WhenDuplicatedUUID_ThenReturnErr(t *testing.T) {
@@ -214,7 +223,7 @@ func TestIntegrCreate_WhenDuplicatedUUID_ThenReturnErr(t *testing.T) {
 	account, err := client.Create(context.Background(), req)
 
 	assert.Empty(t, account)
-	assert.EqualValues(t, err, expErr)
+	assert.EqualError(t, err, expErr.Error())
 }
 
 func TestIntegrCreate_WhenRequestSuccess_ThenCreatedAccount(t *testing.T) {
@@ -248,6 +257,33 @@ func TestIntegrCreate_WhenRequestSuccess_ThenCreatedAccount(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIntegrCreateIdempotent_WhenDuplicatedUUID_ThenReturnExisting(t *testing.T) {
+	client := f3Client.NewClient()
+	accountTest := createTestAccount(t, &client)
+
+	defer cleanTestAccounts(t, &client, accountTest.ID)
+
+	req := f3Client.AccountRequest{
+		ID:             accountTest.ID,
+		OrganisationID: accountTest.OrganisationID,
+		Type:           accountTest.Type,
+		Attributes: &f3Client.AccountAttributesRequest{
+			Name:    accountTest.AccountAttributes.Name,
+			Country: accountTest.AccountAttributes.Country,
+		},
+	}
+
+	account, err := client.CreateIdempotent(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, accountTest, account)
+
+	req.Attributes.Name = []string{"INT_TEST_DATA_another_name"}
+
+	account, err = client.CreateIdempotent(context.Background(), req)
+	assert.ErrorIs(t, err, f3Client.ErrAccountMismatch)
+	assert.Empty(t, account)
+}
+
 func createTestAccount(t *testing.T, c *f3Client.Client) f3Client.Account {
 	account, err := c.Create(context.Background(), f3Client.AccountRequest{
 		ID:             uuid.NewString(),
@@ -291,8 +327,8 @@ func cleanTestAccounts(t *testing.T, c *f3Client.Client, ids ...string) {
 
 func fetchTestAccountByID(t *testing.T, c *f3Client.Client, accountID string) f3Client.Account {
 	account, err := c.Fetch(context.Background(), accountID)
-	switch err.(type) {
-	case *f3Client.RequestError:
+	switch {
+	case err == nil, errors.Is(err, f3Client.ErrRecordNotFound):
 		return account
 	default:
 		t.Fatalf("Integration fetchTestAccountByID error [%s] while trying to fetch id[%s]", err.Error(), accountID)
@@ -0,0 +1,191 @@
+package form3client
+
+import (
+	"context"
+	"net/url"
+	"sync"
+)
+
+// AccountIterator lazily walks every account of every page returned by the
+// accounts list endpoint, following the next link until it is exhausted.
+//
+// Pages are requested in background as soon as the first call to Next is made,
+// up to the prefetch depth provided to Client.Iterator, so the iterator must be
+// released with Close if it is not consumed until the end.
+//
+// To use it, create an instance with Client.Iterator, the zero value of this
+// AccountIterator is not safe to use.
+type AccountIterator struct {
+	client  Client
+	options ListOptions
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	start  sync.Once
+	pages  chan pageResult
+	slots  chan struct{}
+
+	page    []Account
+	account Account
+	err     error
+	done    bool
+}
+
+type pageResult struct {
+	accounts []Account
+	err      error
+}
+
+// Iterator returns an AccountIterator over all the accounts matching the provided options:
+//
+// ctx (context.Context) context that bounds the background page requests, once is done
+// no more pages are requested.
+//
+// options  (ListOptions) first page number, page size and filter criteria of the accounts to walk.
+//
+// prefetchDepth (uint) amount of pages that can be fetched and buffered ahead of
+// the page currently consumed, with 0 a page is only requested once the previous
+// one is consumed.
+func (c *Client) Iterator(ctx context.Context, options ListOptions, prefetchDepth uint) *AccountIterator {
+	ctx, cancel := context.WithCancel(ctx)
+
+	// every page fetched takes a slot and every page consumed releases one, the
+	// page requested by the first call to Next releases the extra slot.
+	slots := make(chan struct{}, prefetchDepth+1)
+	for i := uint(0); i < prefetchDepth; i++ {
+		slots <- struct{}{}
+	}
+
+	return &AccountIterator{
+		client:  *c,
+		options: options,
+		ctx:     ctx,
+		cancel:  cancel,
+		pages:   make(chan pageResult, prefetchDepth),
+		slots:   slots,
+	}
+}
+
+// Next advances the iterator to the following account, waiting for its page to be
+// fetched if needed. It returns false when there are no more accounts, an error
+// happened or the ctx is done, the cause can be obtained with Err.
+func (it *AccountIterator) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	it.start.Do(func() {
+		go it.fetch()
+	})
+
+	if len(it.page) == 0 {
+		it.release()
+	}
+
+	for len(it.page) == 0 {
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			it.Close()
+			return false
+		}
+
+		select {
+		case <-ctx.Done():
+			it.err = ctx.Err()
+			it.Close()
+			return false
+		case result, ok := <-it.pages:
+			if !ok {
+				it.err = it.ctx.Err()
+				it.done = true
+				it.Close()
+				return false
+			}
+
+			if result.err != nil {
+				it.err = result.err
+				it.Close()
+				return false
+			}
+
+			it.page = result.accounts
+		}
+	}
+
+	it.account, it.page = it.page[0], it.page[1:]
+
+	return true
+}
+
+// Account returns the current account of the iterator, it's only valid after a
+// call to Next that returned true.
+func (it *AccountIterator) Account() Account {
+	return it.account
+}
+
+// Err returns the error that stopped the iteration, nil if all the pages were walked.
+func (it *AccountIterator) Err() error {
+	return it.err
+}
+
+// Close stops any background page request, it's safe to call it more than once.
+func (it *AccountIterator) Close() {
+	it.cancel()
+}
+
+// release allows the background fetch to request one more page.
+func (it *AccountIterator) release() {
+	select {
+	case it.slots <- struct{}{}:
+	default:
+	}
+}
+
+func (it *AccountIterator) fetch() {
+	defer close(it.pages)
+
+	var (
+		page AccountPage
+		err  error
+		path string
+	)
+
+	for {
+		select {
+		case <-it.slots:
+		case <-it.ctx.Done():
+			return
+		}
+
+		switch {
+		case err != nil:
+			// the next link of the previous page is malformed, it's reported as this page.
+			page = AccountPage{}
+		case path == "":
+			page, err = it.client.List(it.ctx, it.options)
+		default:
+			page, err = it.client.listPage(it.ctx, path)
+		}
+
+		select {
+		case it.pages <- pageResult{accounts: page.Accounts, err: err}:
+		case <-it.ctx.Done():
+			return
+		}
+
+		if err != nil || !page.HasNext() || len(page.Accounts) == 0 {
+			return
+		}
+
+		path, err = linkPath(page.Links.Next)
+	}
+}
+
+func linkPath(link string) (string, error) {
+	linkURL, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+
+	return linkURL.RequestURI(), nil
+}
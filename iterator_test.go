@@ -0,0 +1,179 @@
+package form3client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterator_WhenSeveralPages_ThenWalksEveryAccountInOrder(t *testing.T) {
+	var (
+		sendReqURLs []string
+		pages       = [][]f3Client.Account{
+			{{ID: uuid.NewString()}, {ID: uuid.NewString()}},
+			{{ID: uuid.NewString()}, {ID: uuid.NewString()}},
+			{{ID: uuid.NewString()}},
+		}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			sendReqURLs = append(sendReqURLs, req.URL.String())
+
+			number := len(sendReqURLs) - 1
+			links := f3Client.Links{}
+			if number < len(pages)-1 {
+				links.Next = fmt.Sprintf("/v1/organisation/accounts?page%%5Bnumber%%5D=%d&page%%5Bsize%%5D=2", number+1)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       getReaderFromInterface(f3Client.AccountListResponse{Accounts: pages[number], Links: links}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	it := c.Iterator(context.Background(), f3Client.ListOptions{PageSize: 2}, 1)
+	defer it.Close()
+
+	var accounts []f3Client.Account
+	for it.Next(context.Background()) {
+		accounts = append(accounts, it.Account())
+	}
+
+	assert.Equal(t, append(append(pages[0], pages[1]...), pages[2]...), accounts)
+	assert.Equal(t, []string{
+		baseURL + "?page%5Bnumber%5D=0&page%5Bsize%5D=2",
+		baseURL + "?page%5Bnumber%5D=1&page%5Bsize%5D=2",
+		baseURL + "?page%5Bnumber%5D=2&page%5Bsize%5D=2",
+	}, sendReqURLs)
+	assert.NoError(t, it.Err())
+}
+
+func TestIterator_WhenPageRequestFails_ThenStopsWithErr(t *testing.T) {
+	var (
+		calls  int32
+		expErr = errors.New("client internal error")
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			if atomic.AddInt32(&calls, 1) > 1 {
+				return nil, expErr
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: getReaderFromInterface(f3Client.AccountListResponse{
+					Accounts: []f3Client.Account{{ID: uuid.NewString()}},
+					Links:    f3Client.Links{Next: "/v1/organisation/accounts?page%5Bnumber%5D=1"},
+				}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	it := c.Iterator(context.Background(), f3Client.ListOptions{}, 0)
+	defer it.Close()
+
+	assert.True(t, it.Next(context.Background()))
+	assert.False(t, it.Next(context.Background()))
+	assert.False(t, it.Next(context.Background()))
+	assert.EqualError(t, it.Err(), expErr.Error())
+}
+
+func TestIterator_WhenContextCancelled_ThenStopsWithCtxErr(t *testing.T) {
+	var (
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: getReaderFromInterface(f3Client.AccountListResponse{
+					Accounts: []f3Client.Account{{ID: uuid.NewString()}},
+					Links:    f3Client.Links{Next: "/v1/organisation/accounts?page%5Bnumber%5D=1"},
+				}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	it := c.Iterator(context.Background(), f3Client.ListOptions{}, 2)
+	defer it.Close()
+
+	assert.True(t, it.Next(ctx))
+
+	cancel()
+
+	assert.False(t, it.Next(ctx))
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
+
+func TestIterator_WhenEmptyPage_ThenFinishesWithoutAccounts(t *testing.T) {
+	var (
+		calls int
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			calls++
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: getReaderFromInterface(f3Client.AccountListResponse{
+					Links: f3Client.Links{Next: "/v1/organisation/accounts?page%5Bnumber%5D=1"},
+				}),
+			}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+	)
+
+	it := c.Iterator(context.Background(), f3Client.ListOptions{}, 0)
+	defer it.Close()
+
+	assert.False(t, it.Next(context.Background()))
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, it.Err())
+}
+
+func TestIterator_WhenPrefetchDepth_ThenFetchesThatManyPagesAhead(t *testing.T) {
+	for _, depth := range []uint{0, 1, 2} {
+		t.Run(fmt.Sprintf("depth %d", depth), func(t *testing.T) {
+			var (
+				calls int32
+
+				doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+					atomic.AddInt32(&calls, 1)
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body: getReaderFromInterface(f3Client.AccountListResponse{
+							Accounts: []f3Client.Account{{ID: uuid.NewString()}},
+							Links:    f3Client.Links{Next: "/v1/organisation/accounts?page%5Bnumber%5D=1"},
+						}),
+					}, nil
+				}
+
+				c        = f3Client.NewClient(f3Client.MockDoer(doerMockFunc))
+				expCalls = int32(depth) + 1
+			)
+
+			it := c.Iterator(context.Background(), f3Client.ListOptions{}, depth)
+			defer it.Close()
+
+			assert.True(t, it.Next(context.Background()))
+			assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == expCalls }, time.Second, time.Millisecond)
+			assert.Never(t, func() bool { return atomic.LoadInt32(&calls) > expCalls }, 50*time.Millisecond, time.Millisecond)
+
+			assert.True(t, it.Next(context.Background()))
+			assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == expCalls+1 }, time.Second, time.Millisecond)
+			assert.Never(t, func() bool { return atomic.LoadInt32(&calls) > expCalls+1 }, 50*time.Millisecond, time.Millisecond)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package form3client
+
+import "net/http"
+
+// Middleware wraps a Doer with additional behaviour, such as retries, tracing, metrics or
+// authentication, returning a Doer that usually delegates the request to next.
+type Middleware func(next Doer) Doer
+
+// DoerFunc allows the use of ordinary functions as Doer.
+type DoerFunc func(client http.Client, req *http.Request) (resp *http.Response, err error)
+
+// Do calls f(client, req).
+func (f DoerFunc) Do(client http.Client, req *http.Request) (resp *http.Response, err error) {
+	return f(client, req)
+}
+
+// RetryMiddleware returns the retry Doer as a Middleware, so retries can be stacked
+// with other middlewares and the client Doer, i.e. a MockDoer.
+//
+// RetryAttempts: type uint specifies the amount of retries attempts, zero means no retries
+//
+// Backoff: type Backoff computes the waits between attempts, a nil Backoff retries without waiting
+//
+// Options: type RetryOption allows to change the RetryPolicy and observe its decisions, see NewRetryDoer
+func RetryMiddleware(retryAttempts uint, backoff Backoff, options ...RetryOption) Middleware {
+	return func(next Doer) Doer {
+		r := newRetryDoer(retryAttempts, append([]RetryOption{WithBackoff(backoff)}, options...)...)
+		r.next = next
+		return r
+	}
+}
+
+// chain wraps the doer with the middlewares, the first middleware being the
+// outermost one, so it's the first to receive the request and the last to
+// receive the response.
+func chain(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+
+	return doer
+}
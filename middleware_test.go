@@ -0,0 +1,100 @@
+package form3client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	f3Client "form3-client-library"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMiddleware_WhenSeveralInstalled_ThenFirstIsOutermost(t *testing.T) {
+	var (
+		calls []string
+
+		trace = func(name string) f3Client.Middleware {
+			return func(next f3Client.Doer) f3Client.Doer {
+				return f3Client.DoerFunc(func(client http.Client, req *http.Request) (*http.Response, error) {
+					calls = append(calls, name+":request")
+					resp, err := next.Do(client, req)
+					calls = append(calls, name+":response")
+					return resp, err
+				})
+			}
+		}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			calls = append(calls, "doer")
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(
+			f3Client.WithMiddleware(trace("first"), trace("second")),
+			f3Client.WithMiddleware(trace("third")),
+			f3Client.MockDoer(doerMockFunc),
+		)
+	)
+
+	err := c.Delete(context.Background(), "test-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"first:request",
+		"second:request",
+		"third:request",
+		"doer",
+		"third:response",
+		"second:response",
+		"first:response",
+	}, calls)
+}
+
+func TestWithMiddleware_WhenHeaderMiddleware_ThenRequestCarriesHeader(t *testing.T) {
+	var (
+		sendAuthHeader string
+
+		auth = func(next f3Client.Doer) f3Client.Doer {
+			return f3Client.DoerFunc(func(client http.Client, req *http.Request) (*http.Response, error) {
+				req.Header.Set("Authorization", "Bearer token")
+				return next.Do(client, req)
+			})
+		}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			sendAuthHeader = req.Header.Get("Authorization")
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc), f3Client.WithMiddleware(auth))
+	)
+
+	err := c.Delete(context.Background(), "test-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token", sendAuthHeader)
+}
+
+func TestRetryMiddleware_WhenStackedWithMockDoer_ThenRetriesMockedResponses(t *testing.T) {
+	var (
+		attempts int
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			if attempts++; attempts < 3 {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+			}
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(
+			f3Client.WithMiddleware(f3Client.RetryMiddleware(3, f3Client.ConstantBackoff(0))),
+			f3Client.MockDoer(doerMockFunc),
+		)
+	)
+
+	err := c.Delete(context.Background(), "test-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
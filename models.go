@@ -6,6 +6,10 @@ type CreateAccountRequest struct {
 	Data AccountRequest `json:"data"`
 }
 
+type UpdateAccountRequest struct {
+	Data AccountPatch `json:"data"`
+}
+
 type AccountRequest struct {
 	Attributes     *AccountAttributesRequest `json:"attributes,omitempty"`
 	ID             string                    `json:"id"`
@@ -15,25 +19,79 @@ type AccountRequest struct {
 }
 
 type AccountAttributesRequest struct {
-	AccountClassification   *string  `json:"account_classification,omitempty"`
-	AccountMatchingOptOut   *bool    `json:"account_matching_opt_out,omitempty"`
-	AccountNumber           string   `json:"account_number,omitempty"`
-	AlternativeNames        []string `json:"alternative_names,omitempty"`
-	BankID                  string   `json:"bank_id,omitempty"`
-	BankIDCode              string   `json:"bank_id_code,omitempty"`
-	BaseCurrency            string   `json:"base_currency,omitempty"`
-	Bic                     string   `json:"bic,omitempty"`
-	Country                 string   `json:"country"`
-	Iban                    string   `json:"iban,omitempty"`
-	JointAccount            *bool    `json:"joint_account,omitempty"`
-	Name                    []string `json:"name"`
-	SecondaryIdentification string   `json:"secondary_identification,omitempty"`
-	Status                  *string  `json:"status,omitempty"`
-	Switched                *bool    `json:"switched,omitempty"`
+	AccountClassification   *AccountClassification `json:"account_classification,omitempty"`
+	AccountMatchingOptOut   *bool                  `json:"account_matching_opt_out,omitempty"`
+	AccountNumber           string                 `json:"account_number,omitempty"`
+	AlternativeNames        []string               `json:"alternative_names,omitempty"`
+	BankID                  string                 `json:"bank_id,omitempty"`
+	BankIDCode              string                 `json:"bank_id_code,omitempty"`
+	BaseCurrency            Currency               `json:"base_currency,omitempty"`
+	Bic                     string                 `json:"bic,omitempty"`
+	Country                 Country                `json:"country"`
+	Iban                    string                 `json:"iban,omitempty"`
+	JointAccount            *bool                  `json:"joint_account,omitempty"`
+	Name                    []string               `json:"name"`
+	SecondaryIdentification string                 `json:"secondary_identification,omitempty"`
+	Status                  *AccountStatus         `json:"status,omitempty"`
+	Switched                *bool                  `json:"switched,omitempty"`
+	UserDefinedData         []UserDefinedData      `json:"user_defined_data,omitempty"`
+}
+
+// AccountPatch is the data sent by Client.Update, unlike AccountRequest every empty
+// field is omitted so only the attributes to modify are sent.
+type AccountPatch struct {
+	Attributes     *AccountAttributesPatch `json:"attributes,omitempty"`
+	ID             string                  `json:"id,omitempty"`
+	OrganisationID string                  `json:"organisation_id,omitempty"`
+	Type           string                  `json:"type,omitempty"`
+	Version        *int64                  `json:"version,omitempty"`
+}
+
+type AccountAttributesPatch struct {
+	AccountClassification   *AccountClassification `json:"account_classification,omitempty"`
+	AccountMatchingOptOut   *bool                  `json:"account_matching_opt_out,omitempty"`
+	AccountNumber           string                 `json:"account_number,omitempty"`
+	AlternativeNames        []string               `json:"alternative_names,omitempty"`
+	BankID                  string                 `json:"bank_id,omitempty"`
+	BankIDCode              string                 `json:"bank_id_code,omitempty"`
+	BaseCurrency            Currency               `json:"base_currency,omitempty"`
+	Bic                     string                 `json:"bic,omitempty"`
+	Country                 Country                `json:"country,omitempty"`
+	Iban                    string                 `json:"iban,omitempty"`
+	JointAccount            *bool                  `json:"joint_account,omitempty"`
+	Name                    []string               `json:"name,omitempty"`
+	SecondaryIdentification string                 `json:"secondary_identification,omitempty"`
+	Status                  *AccountStatus         `json:"status,omitempty"`
+	Switched                *bool                  `json:"switched,omitempty"`
+	UserDefinedData         []UserDefinedData      `json:"user_defined_data,omitempty"`
+}
+
+// newAccountPatch converts the request into a patch, the attributes share the same
+// fields and only differ in their JSON tags.
+func newAccountPatch(r AccountRequest) AccountPatch {
+	patch := AccountPatch{
+		ID:             r.ID,
+		OrganisationID: r.OrganisationID,
+		Type:           r.Type,
+		Version:        r.Version,
+	}
+
+	if r.Attributes != nil {
+		attributes := AccountAttributesPatch(*r.Attributes)
+		patch.Attributes = &attributes
+	}
+
+	return patch
+}
+
+type UserDefinedData struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 type ResponseError struct {
 	ErrorMessage string `json:"error_message"`
+	ErrorCode    string `json:"error_code,omitempty"`
 }
 
 type AccountResponse struct {
@@ -52,11 +110,67 @@ type Account struct {
 }
 
 type AccountAttributes struct {
-	Country          string   `json:"country"`
-	Name             []string `json:"name"`
-	AlternativeNames []string `json:"alternative_names"`
+	AccountClassification   *AccountClassification `json:"account_classification"`
+	AccountMatchingOptOut   *bool                  `json:"account_matching_opt_out"`
+	AccountNumber           string                 `json:"account_number"`
+	AlternativeNames        []string               `json:"alternative_names"`
+	BankID                  string                 `json:"bank_id"`
+	BankIDCode              string                 `json:"bank_id_code"`
+	BaseCurrency            Currency               `json:"base_currency"`
+	Bic                     string                 `json:"bic"`
+	Country                 Country                `json:"country"`
+	Iban                    string                 `json:"iban"`
+	JointAccount            *bool                  `json:"joint_account"`
+	Name                    []string               `json:"name"`
+	SecondaryIdentification string                 `json:"secondary_identification"`
+	Status                  *AccountStatus         `json:"status"`
+	StatusReason            string                 `json:"status_reason"`
+	Switched                *bool                  `json:"switched"`
+	UserDefinedData         []UserDefinedData      `json:"user_defined_data"`
+}
+
+type AccountListResponse struct {
+	Accounts []Account `json:"data"`
+	Links    Links     `json:"links"`
 }
 
 type Links struct {
-	Self string `json:"self"`
+	Self  string `json:"self"`
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// ListOptions contains the pagination and filter criteria used by Client.List.
+//
+// A zero PageSize lets the API apply its own default page size.
+type ListOptions struct {
+	PageNumber uint
+	PageSize   uint
+	Filter     ListFilter
+}
+
+// ListFilter restricts the accounts returned by Client.List, empty
+// values are not sent to the API.
+type ListFilter struct {
+	BankID        string
+	BankIDCode    string
+	AccountNumber string
+	Iban          string
+	Country       Country
+	CustomerID    string
+}
+
+// AccountPage is a single page of accounts returned by Client.List.
+type AccountPage struct {
+	Accounts   []Account
+	Links      Links
+	PageNumber uint
+	PageSize   uint
+}
+
+// HasNext reports whether the API provided a link to a following page.
+func (p AccountPage) HasNext() bool {
+	return p.Links.Next != ""
 }
@@ -0,0 +1,108 @@
+package form3client_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountAttributes_WhenRequestRoundTrip_ThenResponseKeepsEveryAttribute(t *testing.T) {
+	var (
+		classification = f3Client.ClassificationPersonal
+		status         = f3Client.AccountStatusConfirmed
+		optOut         = false
+		joint          = true
+		switched       = false
+
+		req = f3Client.AccountRequest{
+			ID:             uuid.NewString(),
+			OrganisationID: uuid.NewString(),
+			Type:           "accounts",
+			Attributes: &f3Client.AccountAttributesRequest{
+				AccountClassification:   &classification,
+				AccountMatchingOptOut:   &optOut,
+				AccountNumber:           "41426819",
+				AlternativeNames:        []string{"Sam Holder"},
+				BankID:                  "400300",
+				BankIDCode:              "GBDSC",
+				BaseCurrency:            "GBP",
+				Bic:                     "NWBKGB22",
+				Country:                 "GB",
+				Iban:                    "GB11NWBK40030041426819",
+				JointAccount:            &joint,
+				Name:                    []string{"Samantha Holder"},
+				SecondaryIdentification: "A1B2C3D4",
+				Status:                  &status,
+				Switched:                &switched,
+				UserDefinedData:         []f3Client.UserDefinedData{{Key: "Some account related key", Value: "Some account related value"}},
+			},
+		}
+	)
+
+	payload, err := json.Marshal(f3Client.CreateAccountRequest{Data: req})
+	assert.NoError(t, err)
+
+	var resp f3Client.AccountResponse
+	assert.NoError(t, json.Unmarshal(payload, &resp))
+
+	assert.Equal(t, req.ID, resp.Account.ID)
+	assert.Equal(t, req.OrganisationID, resp.Account.OrganisationID)
+	assert.Equal(t, req.Type, resp.Account.Type)
+	assert.Equal(t, f3Client.AccountAttributes{
+		AccountClassification:   req.Attributes.AccountClassification,
+		AccountMatchingOptOut:   req.Attributes.AccountMatchingOptOut,
+		AccountNumber:           req.Attributes.AccountNumber,
+		AlternativeNames:        req.Attributes.AlternativeNames,
+		BankID:                  req.Attributes.BankID,
+		BankIDCode:              req.Attributes.BankIDCode,
+		BaseCurrency:            req.Attributes.BaseCurrency,
+		Bic:                     req.Attributes.Bic,
+		Country:                 req.Attributes.Country,
+		Iban:                    req.Attributes.Iban,
+		JointAccount:            req.Attributes.JointAccount,
+		Name:                    req.Attributes.Name,
+		SecondaryIdentification: req.Attributes.SecondaryIdentification,
+		Status:                  req.Attributes.Status,
+		Switched:                req.Attributes.Switched,
+		UserDefinedData:         req.Attributes.UserDefinedData,
+	}, resp.Account.AccountAttributes)
+}
+
+func TestAccountAttributes_WhenAPIResponse_ThenDecodesServerAttributes(t *testing.T) {
+	payload := `{
+		"data": {
+			"attributes": {
+				"account_classification": "Business",
+				"account_number": "41426819",
+				"bank_id": "400300",
+				"bank_id_code": "GBDSC",
+				"country": "GB",
+				"name": ["Samantha Holder"],
+				"status": "failed",
+				"status_reason": "invalid-account-number",
+				"user_defined_data": [{"key": "reference", "value": "1234"}]
+			},
+			"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			"organisation_id": "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+			"type": "accounts",
+			"version": 1
+		},
+		"links": {"self": "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}
+	}`
+
+	var resp f3Client.AccountResponse
+	assert.NoError(t, json.Unmarshal([]byte(payload), &resp))
+
+	attributes := resp.Account.AccountAttributes
+
+	assert.Equal(t, f3Client.ClassificationBusiness, *attributes.AccountClassification)
+	assert.Equal(t, f3Client.AccountStatusFailed, *attributes.Status)
+	assert.Equal(t, "invalid-account-number", attributes.StatusReason)
+	assert.Equal(t, []f3Client.UserDefinedData{{Key: "reference", Value: "1234"}}, attributes.UserDefinedData)
+	assert.Nil(t, attributes.JointAccount)
+	assert.Equal(t, 1, resp.Account.Version)
+}
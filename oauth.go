@@ -0,0 +1,285 @@
+package form3client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	formContentType     = "application/x-www-form-urlencoded"
+
+	// defaultTokenLifetime is the lifetime of tokens issued without expires_in, a token
+	// revoked earlier is replaced anyway once the API rejects it with 401.
+	defaultTokenLifetime = time.Hour
+)
+
+type tokenConfig struct {
+	scopes       []string
+	expiryMargin time.Duration
+	httpClient   *http.Client
+	clock        Clock
+}
+
+// TokenOption is any function that can work as an option to set TokenSource
+// features, following the same Functional Options approach as ClientOption.
+type TokenOption func(tokenConfig) tokenConfig
+
+// TokenScopes specifies the scopes requested with the token, by default none.
+func TokenScopes(scopes ...string) TokenOption {
+	return func(c tokenConfig) tokenConfig {
+		c.scopes = append([]string(nil), scopes...)
+		return c
+	}
+}
+
+// TokenExpiryMargin specifies how long before its expiry a token is refreshed, so
+// it doesn't expire while a request is in flight, by default 30 seconds.
+//
+// The margin never exceeds half the lifetime of a token, so short lived tokens are still
+// cached. Tokens issued without expires_in are considered valid for an hour.
+func TokenExpiryMargin(margin time.Duration) TokenOption {
+	return func(c tokenConfig) tokenConfig {
+		c.expiryMargin = margin
+		return c
+	}
+}
+
+// TokenHTTPClient specifies the http.Client used to request tokens, by default one
+// with a 10 seconds timeout.
+func TokenHTTPClient(client *http.Client) TokenOption {
+	return func(c tokenConfig) tokenConfig {
+		if client != nil {
+			c.httpClient = client
+		}
+		return c
+	}
+}
+
+// TokenClock specifies the Clock used to compute the token expiry, by default
+// the real time is used.
+func TokenClock(clock Clock) TokenOption {
+	return func(c tokenConfig) tokenConfig {
+		if clock != nil {
+			c.clock = clock
+		}
+		return c
+	}
+}
+
+// TokenSource obtains bearer tokens with the OAuth2 client credentials grant and caches
+// them until shortly before they expire.
+//
+// Concurrent requests for an expired token share a single token request. It's safe for
+// concurrent use and can be shared by several Clients.
+//
+// To use it, create an instance with NewTokenSource, the zero value of this
+// TokenSource is not safe to use.
+type TokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	config       tokenConfig
+
+	mu      sync.Mutex
+	token   string
+	expiry  time.Time
+	refresh *tokenCall
+}
+
+// tokenCall is a token request in flight, whose result is shared by every caller.
+type tokenCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// NewTokenSource returns a TokenSource that requests tokens to the tokenURL authenticating
+// with the clientID and clientSecret, default settings will be applied if no options are injected.
+func NewTokenSource(tokenURL, clientID, clientSecret string, options ...TokenOption) *TokenSource {
+	config := tokenConfig{
+		expiryMargin: 30 * time.Second,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		clock:        realClock{},
+	}
+
+	for _, option := range options {
+		config = option(config)
+	}
+
+	return &TokenSource{
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		config:       config,
+	}
+}
+
+// Token returns the cached token, requesting a new one if it's missing or about to expire.
+//
+// When a token request is already in flight it waits for its result instead, until the
+// ctx is done. Failures are returned wrapping ErrTokenRequest.
+func (ts *TokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+
+	if ts.token != "" && ts.config.clock.Now().Before(ts.expiry) {
+		token := ts.token
+		ts.mu.Unlock()
+		return token, nil
+	}
+
+	call := ts.refresh
+	if call == nil {
+		call = &tokenCall{done: make(chan struct{})}
+		ts.refresh = call
+
+		go ts.fetch(call)
+	}
+
+	ts.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-call.done:
+		return call.token, call.err
+	}
+}
+
+// Invalidate discards the cached token if it's still the provided one, so the next call
+// to Token requests a new one, i.e. after the API rejected it.
+func (ts *TokenSource) Invalidate(token string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token == token {
+		ts.token = ""
+	}
+}
+
+// Middleware returns the TokenSource as a Middleware to be installed with WithMiddleware.
+//
+// It sets the bearer token in the Authorization header of every request and, if the API
+// responds 401, requests a new token and retries once when the request body can be rewound.
+func (ts *TokenSource) Middleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(client http.Client, req *http.Request) (*http.Response, error) {
+			token, err := ts.Token(req.Context())
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next.Do(client, authorize(req, token))
+			if err != nil || resp.StatusCode != http.StatusUnauthorized || !isRewindable(req) {
+				return resp, err
+			}
+
+			retryReq, err := rewindBody(req)
+			if err != nil {
+				return resp, nil
+			}
+
+			ts.Invalidate(token)
+
+			if token, err = ts.Token(req.Context()); err != nil {
+				return resp, nil
+			}
+
+			discardBody(resp)
+
+			return next.Do(client, authorize(retryReq, token))
+		})
+	}
+}
+
+// fetch makes the token request detached from the callers context, so callers that
+// give up don't fail the ones still waiting for it, bounded by the token http.Client timeout.
+func (ts *TokenSource) fetch(call *tokenCall) {
+	token, expiresIn, err := ts.requestToken(context.Background())
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if err == nil {
+		if expiresIn <= 0 {
+			expiresIn = defaultTokenLifetime
+		}
+
+		margin := ts.config.expiryMargin
+		if margin > expiresIn/2 {
+			margin = expiresIn / 2
+		}
+
+		ts.token = token
+		ts.expiry = ts.config.clock.Now().Add(expiresIn - margin)
+	}
+
+	call.token, call.err = token, err
+	ts.refresh = nil
+	close(call.done)
+}
+
+func (ts *TokenSource) requestToken(ctx context.Context) (string, time.Duration, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(ts.config.scopes) > 0 {
+		form.Set("scope", strings.Join(ts.config.scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %s", ErrTokenRequest, err)
+	}
+
+	req.Header.Set(contentTypeHeader, formContentType)
+	req.SetBasicAuth(url.QueryEscape(ts.clientID), url.QueryEscape(ts.clientSecret))
+
+	resp, err := ts.config.httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %w", ErrTokenRequest, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %w", ErrTokenRequest, err)
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(content, &token); err != nil && resp.StatusCode == http.StatusOK {
+		return "", 0, fmt.Errorf("%w: %w", ErrTokenRequest, ErrUnmarshalInvalidValue)
+	}
+
+	switch {
+	case resp.StatusCode != http.StatusOK:
+		return "", 0, fmt.Errorf("%w: status %d %s %s", ErrTokenRequest, resp.StatusCode, token.Error, token.ErrorDescription)
+	case token.AccessToken == "":
+		return "", 0, fmt.Errorf("%w: empty access token", ErrTokenRequest)
+	case token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer"):
+		return "", 0, fmt.Errorf("%w: unsupported token type %s", ErrTokenRequest, token.TokenType)
+	}
+
+	return token.AccessToken, time.Duration(token.ExpiresIn) * time.Second, nil
+}
+
+// authorize returns a copy of the request with the bearer token, leaving the
+// caller request untouched.
+func authorize(req *http.Request, token string) *http.Request {
+	authReq := req.Clone(req.Context())
+	authReq.Header.Set(authorizationHeader, "Bearer "+token)
+	return authReq
+}
@@ -0,0 +1,229 @@
+package form3client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTokenServer returns a token server issuing tok-1, tok-2... that expire in an hour,
+// after waiting for release when it's not nil.
+func newTokenServer(t *testing.T, requests *int32, release <-chan struct{}) *httptest.Server {
+	return newTokenServerWithExpiry(t, requests, release, `,"expires_in":3600`)
+}
+
+// newTokenServerWithExpiry returns a token server whose responses end with the expiry
+// JSON field, i.e. empty to issue tokens without expires_in.
+func newTokenServerWithExpiry(t *testing.T, requests *int32, release <-chan struct{}, expiry string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "client-id", id)
+		assert.Equal(t, "client-secret", secret)
+		assert.Equal(t, "client_credentials", r.PostFormValue("grant_type"))
+
+		if release != nil {
+			<-release
+		}
+
+		n := atomic.AddInt32(requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"tok-%d","token_type":"Bearer"%s}`, n, expiry)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestOAuth2ClientCredentials_WhenSeveralRequests_ThenReusesCachedToken(t *testing.T) {
+	var (
+		tokenRequests int32
+		authorization []string
+
+		tokenServer  = newTokenServer(t, &tokenRequests, nil)
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			authorization = append(authorization, req.Header.Get("Authorization"))
+			return &http.Response{StatusCode: http.StatusOK, Body: getReaderFromInterface(f3Client.AccountResponse{})}, nil
+		}
+
+		c = f3Client.NewClient(
+			f3Client.MockDoer(doerMockFunc),
+			f3Client.OAuth2ClientCredentials(tokenServer.URL, "client-id", "client-secret"),
+		)
+	)
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Fetch(context.Background(), uuid.NewString())
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+	assert.Equal(t, []string{"Bearer tok-1", "Bearer tok-1", "Bearer tok-1"}, authorization)
+}
+
+func TestTokenSource_WhenTokenAboutToExpire_ThenRequestsNewOne(t *testing.T) {
+	var (
+		tokenRequests int32
+
+		clock       = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+		tokenServer = newTokenServer(t, &tokenRequests, nil)
+		source      = f3Client.NewTokenSource(tokenServer.URL, "client-id", "client-secret",
+			f3Client.TokenClock(clock), f3Client.TokenExpiryMargin(time.Minute))
+	)
+
+	token, err := source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "tok-1", token)
+
+	clock.now = clock.now.Add(58 * time.Minute)
+
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "tok-1", token)
+
+	clock.now = clock.now.Add(time.Minute)
+
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "tok-2", token)
+}
+
+func TestTokenSource_WhenShortOrMissingExpiry_ThenCachesToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		expiry   string
+		validFor time.Duration
+	}{
+		{"missing expires_in", "", time.Hour - 30*time.Second},
+		{"expires_in shorter than margin", `,"expires_in":20`, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				tokenRequests int32
+
+				clock       = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+				tokenServer = newTokenServerWithExpiry(t, &tokenRequests, nil, tt.expiry)
+				source      = f3Client.NewTokenSource(tokenServer.URL, "client-id", "client-secret", f3Client.TokenClock(clock))
+			)
+
+			for i := 0; i < 3; i++ {
+				token, err := source.Token(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, "tok-1", token)
+			}
+
+			clock.now = clock.now.Add(tt.validFor - time.Second)
+
+			token, err := source.Token(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "tok-1", token)
+
+			clock.now = clock.now.Add(time.Second)
+
+			token, err = source.Token(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "tok-2", token)
+			assert.Equal(t, int32(2), atomic.LoadInt32(&tokenRequests))
+		})
+	}
+}
+
+func TestTokenSource_WhenConcurrentCallers_ThenRequestsSingleToken(t *testing.T) {
+	var (
+		tokenRequests int32
+		wg            sync.WaitGroup
+		tokens        = make([]string, 20)
+
+		release     = make(chan struct{})
+		tokenServer = newTokenServer(t, &tokenRequests, release)
+		source      = f3Client.NewTokenSource(tokenServer.URL, "client-id", "client-secret")
+	)
+
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], _ = source.Token(context.Background())
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+	for _, token := range tokens {
+		assert.Equal(t, "tok-1", token)
+	}
+}
+
+func TestOAuth2ClientCredentials_WhenUnauthorized_ThenRetriesOnceWithNewToken(t *testing.T) {
+	var (
+		tokenRequests int32
+		authorization []string
+		bodies        []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body [64]byte
+		n, _ := r.Body.Read(body[:])
+		bodies = append(bodies, string(body[:n]))
+
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") == "Bearer tok-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"id":"test-id"}}`))
+	}))
+	defer server.Close()
+
+	var (
+		tokenServer = newTokenServer(t, &tokenRequests, nil)
+		c           = f3Client.NewClient(
+			f3Client.BaseURL(server.URL),
+			f3Client.OAuth2ClientCredentials(tokenServer.URL, "client-id", "client-secret"),
+		)
+	)
+
+	account, err := c.Create(context.Background(), f3Client.AccountRequest{ID: "test-id"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test-id", account.ID)
+	assert.Equal(t, []string{"Bearer tok-1", "Bearer tok-2"}, authorization)
+	assert.Equal(t, bodies[0], bodies[1])
+	assert.NotEmpty(t, bodies[0])
+	assert.Equal(t, int32(2), atomic.LoadInt32(&tokenRequests))
+}
+
+func TestTokenSource_WhenTokenEndpointFails_ThenErrTokenRequest(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"unknown client"}`))
+	}))
+	defer tokenServer.Close()
+
+	c := f3Client.NewClient(
+		f3Client.MockDoer(nil),
+		f3Client.OAuth2ClientCredentials(tokenServer.URL, "client-id", "client-secret"),
+	)
+
+	_, err := c.Fetch(context.Background(), uuid.NewString())
+
+	assert.ErrorIs(t, err, f3Client.ErrTokenRequest)
+	assert.EqualError(t, err, "unable to obtain OAuth2 token: status 400 invalid_client unknown client")
+}
@@ -0,0 +1,188 @@
+package form3client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that allows requestsPerSecond on average with bursts
+// of up to burst requests. It's safe for concurrent use.
+//
+// To use it, create an instance with NewRateLimiter, the zero value of this
+// RateLimiter is not safe to use.
+type RateLimiter struct {
+	rate  float64
+	burst float64
+	clock Clock
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a full RateLimiter that refills requestsPerSecond tokens per
+// second up to burst tokens. A requestsPerSecond of zero or less means no limit and a
+// burst of zero is considered a burst of one.
+func NewRateLimiter(requestsPerSecond float64, burst uint) *RateLimiter {
+	return newRateLimiter(requestsPerSecond, burst, realClock{})
+}
+
+func newRateLimiter(requestsPerSecond float64, burst uint, clock Clock) *RateLimiter {
+	if burst == 0 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		clock:  clock,
+		tokens: float64(burst),
+		last:   clock.Now(),
+	}
+}
+
+// Wait blocks until a token is available, returning how long it waited.
+//
+// If the ctx is done before, or its deadline would be exceeded by the wait, the token is
+// given back and the ctx error is returned without waiting any further.
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	if l.rate <= 0 {
+		return 0, nil
+	}
+
+	wait := l.reserve()
+	if wait <= 0 {
+		return 0, nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && l.clock.Now().Add(wait).After(deadline) {
+		l.cancel()
+		return 0, context.DeadlineExceeded
+	}
+
+	timer, stop := l.clock.NewTimer(wait)
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return 0, ctx.Err()
+	case <-timer:
+		return wait, nil
+	}
+}
+
+// reserve takes a token, that may be owed, and returns how long until it's available.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+}
+
+type methodRateLimit struct {
+	requestsPerSecond float64
+	burst             uint
+}
+
+type rateLimitConfig struct {
+	methods map[string]methodRateLimit
+	onWait  func(req *http.Request, waited time.Duration)
+	clock   Clock
+}
+
+// RateLimitOption is any function that can work as an option to set the RateLimit
+// features, following the same Functional Options approach as ClientOption.
+type RateLimitOption func(rateLimitConfig) rateLimitConfig
+
+// MethodRateLimit specifies a separate token bucket for the requests of an HTTP method,
+// which are then only limited by it instead of the Client one.
+func MethodRateLimit(method string, requestsPerSecond float64, burst uint) RateLimitOption {
+	return func(c rateLimitConfig) rateLimitConfig {
+		methods := make(map[string]methodRateLimit, len(c.methods)+1)
+		for m, limit := range c.methods {
+			methods[m] = limit
+		}
+
+		methods[strings.ToUpper(method)] = methodRateLimit{requestsPerSecond, burst}
+		c.methods = methods
+
+		return c
+	}
+}
+
+// OnRateLimitWait specifies a function called with the time every request waited
+// for the rate limiter, zero when it didn't wait.
+func OnRateLimitWait(onWait func(req *http.Request, waited time.Duration)) RateLimitOption {
+	return func(c rateLimitConfig) rateLimitConfig {
+		c.onWait = onWait
+		return c
+	}
+}
+
+// RateLimitClock specifies the Clock used to refill and wait for tokens, by default
+// the real time is used.
+func RateLimitClock(clock Clock) RateLimitOption {
+	return func(c rateLimitConfig) rateLimitConfig {
+		if clock != nil {
+			c.clock = clock
+		}
+		return c
+	}
+}
+
+func rateLimitMiddleware(requestsPerSecond float64, burst uint, options ...RateLimitOption) Middleware {
+	config := rateLimitConfig{clock: realClock{}}
+	for _, option := range options {
+		config = option(config)
+	}
+
+	var (
+		limiter  = newRateLimiter(requestsPerSecond, burst, config.clock)
+		limiters = make(map[string]*RateLimiter, len(config.methods))
+	)
+
+	for method, limit := range config.methods {
+		limiters[method] = newRateLimiter(limit.requestsPerSecond, limit.burst, config.clock)
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(client http.Client, req *http.Request) (*http.Response, error) {
+			methodLimiter, ok := limiters[req.Method]
+			if !ok {
+				methodLimiter = limiter
+			}
+
+			waited, err := methodLimiter.Wait(req.Context())
+			if err != nil {
+				return nil, err
+			}
+
+			if config.onWait != nil {
+				config.onWait(req, waited)
+			}
+
+			return next.Do(client, req)
+		})
+	}
+}
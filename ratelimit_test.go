@@ -0,0 +1,129 @@
+package form3client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	f3Client "form3-client-library"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_WhenBurstExhausted_ThenWaitsForNextToken(t *testing.T) {
+	limiter := f3Client.NewRateLimiter(1000, 2)
+
+	waits := make([]time.Duration, 0, 3)
+	for i := 0; i < 3; i++ {
+		waited, err := limiter.Wait(context.Background())
+		assert.NoError(t, err)
+		waits = append(waits, waited)
+	}
+
+	assert.Zero(t, waits[0])
+	assert.Zero(t, waits[1])
+	assert.Greater(t, waits[2], time.Duration(0))
+	assert.LessOrEqual(t, waits[2], time.Millisecond)
+}
+
+func TestRateLimiter_WhenWaitExceedsDeadline_ThenFailsWithoutWaiting(t *testing.T) {
+	limiter := f3Client.NewRateLimiter(0.1, 1)
+
+	_, err := limiter.Wait(context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	waited, err := limiter.Wait(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, waited)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestRateLimit_WhenClientExceedsRate_ThenReportsWaits(t *testing.T) {
+	var (
+		waits []time.Duration
+		clock = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(
+			f3Client.MockDoer(doerMockFunc),
+			f3Client.RateLimit(2, 1,
+				f3Client.RateLimitClock(clock),
+				f3Client.OnRateLimitWait(func(req *http.Request, waited time.Duration) {
+					waits = append(waits, waited)
+				}),
+			),
+		)
+	)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, c.Delete(context.Background(), "test-id"))
+	}
+
+	assert.Equal(t, []time.Duration{0, 500 * time.Millisecond, 500 * time.Millisecond}, waits)
+}
+
+func TestRateLimit_WhenMethodRateLimit_ThenLimitsMethodSeparately(t *testing.T) {
+	var (
+		waits = map[string][]time.Duration{}
+		clock = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			if req.Method == http.MethodDelete {
+				return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(
+			f3Client.MockDoer(doerMockFunc),
+			f3Client.RateLimit(100, 10,
+				f3Client.MethodRateLimit(http.MethodDelete, 1, 1),
+				f3Client.RateLimitClock(clock),
+				f3Client.OnRateLimitWait(func(req *http.Request, waited time.Duration) {
+					waits[req.Method] = append(waits[req.Method], waited)
+				}),
+			),
+		)
+	)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, c.Delete(context.Background(), "test-id"))
+		_, err := c.Fetch(context.Background(), "test-id")
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []time.Duration{0, time.Second}, waits[http.MethodDelete])
+	assert.Equal(t, []time.Duration{0, 0}, waits[http.MethodGet])
+}
+
+func TestRateLimit_WhenContextCancelled_ThenFailsWithCtxErr(t *testing.T) {
+	var (
+		calls int
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			calls++
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc), f3Client.RateLimit(0.01, 1))
+	)
+
+	assert.NoError(t, c.Delete(context.Background(), "test-id"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.Delete(ctx, "test-id")
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,135 @@
+package form3client
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	retryAfterHeader = "Retry-After"
+)
+
+// RetryDecision is the classification made by a RetryPolicy of a single request attempt.
+//
+// Retry: reports whether the request must be attempted again.
+//
+// Wait: when greater than zero overrides the backoff interval before the next attempt,
+// for instance with the value of the Retry-After response header.
+//
+// Reason: human readable explanation of the decision, intended for logging.
+type RetryDecision struct {
+	Retry  bool
+	Wait   time.Duration
+	Reason string
+}
+
+// RetryPolicy classifies the outcome of a request attempt, either a response or
+// an error, deciding if it must be retried.
+//
+// The response Body must not be read by the policy, as it's returned to the caller
+// when no retry is made.
+type RetryPolicy interface {
+	Decide(req *http.Request, resp *http.Response, err error) RetryDecision
+}
+
+// RetryPolicyFunc allows the use of ordinary functions as RetryPolicy.
+type RetryPolicyFunc func(req *http.Request, resp *http.Response, err error) RetryDecision
+
+// Decide calls f(req, resp, err).
+func (f RetryPolicyFunc) Decide(req *http.Request, resp *http.Response, err error) RetryDecision {
+	return f(req, resp, err)
+}
+
+// DefaultRetryPolicy is the RetryPolicy used by NewRetryDoer when none is provided.
+//
+// Idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE) are retried on transport errors,
+// timeouts and 429, 500, 502, 503 and 504 responses, while non idempotent methods
+// (POST, PATCH) are only retried on transport errors other than timeouts and on 429 and 503
+// responses, where the server signals the request was not processed. Requests with an
+// Idempotency-Key header are considered idempotent whatever their method.
+//
+// The Retry-After header is honoured both in seconds and HTTP-date formats.
+var DefaultRetryPolicy = NewDefaultRetryPolicy(realClock{})
+
+// NewDefaultRetryPolicy returns the DefaultRetryPolicy using the provided Clock to
+// resolve Retry-After HTTP-dates.
+func NewDefaultRetryPolicy(clock Clock) RetryPolicy {
+	return defaultRetryPolicy{clock: clock}
+}
+
+type defaultRetryPolicy struct {
+	clock Clock
+}
+
+func (p defaultRetryPolicy) Decide(req *http.Request, resp *http.Response, err error) RetryDecision {
+	idempotent := isIdempotent(req.Method) || req.Header.Get(idempotencyKeyHeader) != ""
+
+	if err != nil {
+		switch {
+		case req.Context().Err() != nil:
+			return RetryDecision{Reason: fmt.Sprintf("request context done: %s", req.Context().Err())}
+		case os.IsTimeout(err) && !idempotent:
+			return RetryDecision{Reason: fmt.Sprintf("timeout on non idempotent method %s", req.Method)}
+		case os.IsTimeout(err):
+			return RetryDecision{Retry: true, Reason: fmt.Sprintf("timeout on idempotent method %s", req.Method)}
+		default:
+			return RetryDecision{Retry: true, Reason: fmt.Sprintf("transport error: %s", err)}
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		wait, _ := parseRetryAfter(resp.Header.Get(retryAfterHeader), p.clock.Now())
+		return RetryDecision{Retry: true, Wait: wait, Reason: fmt.Sprintf("retryable status %d", resp.StatusCode)}
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		if !idempotent {
+			return RetryDecision{Reason: fmt.Sprintf("status %d on non idempotent method %s", resp.StatusCode, req.Method)}
+		}
+
+		wait, _ := parseRetryAfter(resp.Header.Get(retryAfterHeader), p.clock.Now())
+		return RetryDecision{Retry: true, Wait: wait, Reason: fmt.Sprintf("retryable status %d", resp.StatusCode)}
+	default:
+		return RetryDecision{Reason: fmt.Sprintf("non retryable status %d", resp.StatusCode)}
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter returns the interval specified by a Retry-After header value, either
+// as delay seconds or as an HTTP-date relative to now.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+
+	return 0, true
+}
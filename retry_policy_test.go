@@ -0,0 +1,112 @@
+package form3client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	f3Client "form3-client-library"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string { return "i/o timeout" }
+func (timeoutErr) Timeout() bool { return true }
+
+func TestDefaultRetryPolicy_WhenClassifyingOutcomes_ThenDecidesByMethodAndStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		status   int
+		err      error
+		expRetry bool
+	}{
+		{"get transport error", http.MethodGet, 0, errors.New("connection refused"), true},
+		{"post transport error", http.MethodPost, 0, errors.New("connection refused"), true},
+		{"get timeout", http.MethodGet, 0, timeoutErr{}, true},
+		{"delete timeout", http.MethodDelete, 0, timeoutErr{}, true},
+		{"post timeout", http.MethodPost, 0, timeoutErr{}, false},
+		{"get ok", http.MethodGet, http.StatusOK, nil, false},
+		{"get bad request", http.MethodGet, http.StatusBadRequest, nil, false},
+		{"post conflict", http.MethodPost, http.StatusConflict, nil, false},
+		{"get too many requests", http.MethodGet, http.StatusTooManyRequests, nil, true},
+		{"post too many requests", http.MethodPost, http.StatusTooManyRequests, nil, true},
+		{"post service unavailable", http.MethodPost, http.StatusServiceUnavailable, nil, true},
+		{"get internal error", http.MethodGet, http.StatusInternalServerError, nil, true},
+		{"delete bad gateway", http.MethodDelete, http.StatusBadGateway, nil, true},
+		{"get gateway timeout", http.MethodGet, http.StatusGatewayTimeout, nil, true},
+		{"post internal error", http.MethodPost, http.StatusInternalServerError, nil, false},
+		{"patch bad gateway", http.MethodPatch, http.StatusBadGateway, nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var resp *http.Response
+			if test.err == nil {
+				resp = &http.Response{StatusCode: test.status, Header: http.Header{}}
+			}
+
+			req, _ := http.NewRequest(test.method, "http://accountapi:8080", nil)
+
+			decision := f3Client.DefaultRetryPolicy.Decide(req, resp, test.err)
+
+			assert.Equal(t, test.expRetry, decision.Retry)
+			assert.NotEmpty(t, decision.Reason)
+		})
+	}
+}
+
+func TestDefaultRetryPolicy_WhenContextDone_ThenDoesNotRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://accountapi:8080", nil)
+
+	decision := f3Client.DefaultRetryPolicy.Decide(req, nil, context.Canceled)
+
+	assert.False(t, decision.Retry)
+	assert.Contains(t, decision.Reason, "context")
+}
+
+func TestDefaultRetryPolicy_WhenRetryAfterSeconds_ThenWaitsSpecifiedSeconds(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://accountapi:8080", nil)
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"120"}},
+	}
+
+	decision := f3Client.DefaultRetryPolicy.Decide(req, resp, nil)
+
+	assert.True(t, decision.Retry)
+	assert.Equal(t, 120*time.Second, decision.Wait)
+}
+
+func TestDefaultRetryPolicy_WhenRetryAfterDate_ThenWaitsUntilDate(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://accountapi:8080", nil)
+	resp := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}},
+	}
+
+	decision := f3Client.DefaultRetryPolicy.Decide(req, resp, nil)
+
+	assert.True(t, decision.Retry)
+	assert.InDelta(t, float64(time.Hour), float64(decision.Wait), float64(2*time.Second))
+}
+
+func TestDefaultRetryPolicy_WhenRetryAfterInvalid_ThenUsesBackoff(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://accountapi:8080", nil)
+	resp := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": {"soon"}},
+	}
+
+	decision := f3Client.DefaultRetryPolicy.Decide(req, resp, nil)
+
+	assert.True(t, decision.Retry)
+	assert.Zero(t, decision.Wait)
+}
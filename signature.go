@@ -0,0 +1,382 @@
+package form3client
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	dateHeader   = "Date"
+	digestHeader = "Digest"
+
+	requestTargetHeader = "(request-target)"
+	signatureScheme     = "Signature"
+)
+
+type signerConfig struct {
+	clock Clock
+}
+
+// SignerOption is any function that can work as an option to set RequestSigner
+// features, following the same Functional Options approach as ClientOption.
+type SignerOption func(signerConfig) signerConfig
+
+// SignerClock specifies the Clock used to set the Date header of the requests, by
+// default the real time is used.
+func SignerClock(clock Clock) SignerOption {
+	return func(c signerConfig) signerConfig {
+		if clock != nil {
+			c.clock = clock
+		}
+		return c
+	}
+}
+
+// RequestSigner authenticates requests with HTTP message signatures as specified by
+// https://datatracker.ietf.org/doc/html/draft-cavage-http-signatures-12, signing the
+// (request-target), host and date headers, and the digest and content-length ones of
+// requests with a body.
+//
+// The signature is sent in the Authorization header using the rsa-sha256 algorithm for
+// RSA keys and ed25519 for Ed25519 keys. It's safe for concurrent use.
+//
+// To use it, create an instance with NewRequestSigner, the zero value of this
+// RequestSigner is not safe to use.
+type RequestSigner struct {
+	keyID     string
+	key       crypto.Signer
+	algorithm string
+	config    signerConfig
+}
+
+// NewRequestSigner returns a RequestSigner that signs with the key identified by keyID
+// in the API, it fails with ErrUnsupportedKey if the key is not an RSA or Ed25519 one.
+//
+// Keys in PEM format can be loaded with ParsePrivateKeyPEM.
+func NewRequestSigner(keyID string, key crypto.Signer, options ...SignerOption) (*RequestSigner, error) {
+	algorithm, err := signatureAlgorithm(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	config := signerConfig{clock: realClock{}}
+	for _, option := range options {
+		config = option(config)
+	}
+
+	return &RequestSigner{keyID: keyID, key: key, algorithm: algorithm, config: config}, nil
+}
+
+// Middleware returns the RequestSigner as a Middleware to be installed with WithMiddleware.
+func (s *RequestSigner) Middleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(client http.Client, req *http.Request) (*http.Response, error) {
+			signedReq, err := s.Sign(req)
+			if err != nil {
+				return nil, err
+			}
+
+			return next.Do(client, signedReq)
+		})
+	}
+}
+
+// Sign returns a copy of the request with the Date, Digest and Authorization headers set,
+// leaving the headers of the provided request untouched.
+//
+// A body without GetBody is buffered, so both the provided request and the copy keep
+// their own reader of the whole body.
+func (s *RequestSigner) Sign(req *http.Request) (*http.Request, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	signedReq := req.Clone(req.Context())
+	if body != nil {
+		signedReq.Body = io.NopCloser(bytes.NewReader(body))
+		signedReq.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	if signedReq.Header.Get(dateHeader) == "" {
+		signedReq.Header.Set(dateHeader, s.config.clock.Now().UTC().Format(http.TimeFormat))
+	}
+
+	headers := []string{requestTargetHeader, "host", "date"}
+	if len(body) > 0 {
+		digest := sha256.Sum256(body)
+		signedReq.Header.Set(digestHeader, "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]))
+		signedReq.ContentLength = int64(len(body))
+
+		headers = append(headers, "digest", "content-length")
+	}
+
+	signingString, err := buildSigningString(signedReq, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.sign([]byte(signingString))
+	if err != nil {
+		return nil, err
+	}
+
+	signedReq.Header.Set(authorizationHeader, fmt.Sprintf(`%s keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		signatureScheme, s.keyID, s.algorithm, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature)))
+
+	return signedReq, nil
+}
+
+func (s *RequestSigner) sign(message []byte) ([]byte, error) {
+	if s.algorithm == "ed25519" {
+		return s.key.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+
+	digest := sha256.Sum256(message)
+	return s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// VerifyRequestSignature checks the Authorization signature of a request made with
+// a RequestSigner, intended for tests and fake APIs:
+//
+// req (*http.Request) the received request, its body is read and restored.
+//
+// publicKey (func(keyID string) (crypto.PublicKey, error)) returns the public key of the
+// keyId of the signature, keys in PEM format can be loaded with ParsePublicKeyPEM.
+//
+// It fails with ErrInvalidSignature if the signature, or the digest of the body, doesn't match.
+func VerifyRequestSignature(req *http.Request, publicKey func(keyID string) (crypto.PublicKey, error)) error {
+	params, err := parseSignatureParams(req.Header.Get(authorizationHeader))
+	if err != nil {
+		return err
+	}
+
+	key, err := publicKey(params["keyId"])
+	if err != nil {
+		return err
+	}
+
+	algorithm, err := signatureAlgorithm(key)
+	if err != nil {
+		return err
+	}
+
+	if params["algorithm"] != "" && params["algorithm"] != algorithm && params["algorithm"] != "hs2019" {
+		return fmt.Errorf("%w: algorithm %s doesn't match the key", ErrInvalidSignature, params["algorithm"])
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+
+	headers := strings.Fields(params["headers"])
+	if len(headers) == 0 {
+		headers = []string{"date"}
+	}
+
+	if len(body) > 0 {
+		digest := sha256.Sum256(body)
+		if req.Header.Get(digestHeader) != "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]) ||
+			!contains(headers, "digest") {
+			return fmt.Errorf("%w: digest doesn't match the body", ErrInvalidSignature)
+		}
+	}
+
+	signingString, err := buildSigningString(req, headers)
+	if err != nil {
+		return err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, []byte(signingString), signature) {
+			return ErrInvalidSignature
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256([]byte(signingString))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrInvalidSignature
+		}
+	}
+
+	return nil
+}
+
+// ParsePrivateKeyPEM parses an RSA or Ed25519 private key in PKCS #8 or PKCS #1 PEM format.
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrUnsupportedKey)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKey, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+	}
+
+	if _, err := signatureAlgorithm(signer.Public()); err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+// ParsePublicKeyPEM parses an RSA or Ed25519 public key in PKIX or PKCS #1 PEM format.
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrUnsupportedKey)
+	}
+
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKey, err)
+	}
+
+	if _, err := signatureAlgorithm(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func signatureAlgorithm(key crypto.PublicKey) (string, error) {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return "rsa-sha256", nil
+	case ed25519.PublicKey:
+		return "ed25519", nil
+	default:
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+	}
+}
+
+// buildSigningString joins the lowercase name and value of every signed header.
+func buildSigningString(req *http.Request, headers []string) (string, error) {
+	lines := make([]string, 0, len(headers))
+
+	for _, header := range headers {
+		var value string
+
+		switch header = strings.ToLower(header); header {
+		case requestTargetHeader:
+			value = fmt.Sprintf("%s %s", strings.ToLower(req.Method), req.URL.RequestURI())
+		case "host":
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		case "content-length":
+			value = strconv.FormatInt(req.ContentLength, 10)
+		default:
+			values := req.Header.Values(header)
+			if len(values) == 0 {
+				return "", fmt.Errorf("%w: missing signed header %s", ErrInvalidSignature, header)
+			}
+
+			value = strings.Join(values, ", ")
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: %s", header, value))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// parseSignatureParams parses the key="value" pairs of a Signature authorization.
+func parseSignatureParams(authorization string) (map[string]string, error) {
+	scheme, value, _ := strings.Cut(authorization, " ")
+	if !strings.EqualFold(scheme, signatureScheme) {
+		return nil, fmt.Errorf("%w: missing Signature authorization", ErrInvalidSignature)
+	}
+
+	params := map[string]string{}
+	for _, param := range strings.Split(value, ",") {
+		name, quoted, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed parameter %q", ErrInvalidSignature, param)
+		}
+
+		params[name] = strings.Trim(quoted, `"`)
+	}
+
+	if params["keyId"] == "" || params["signature"] == "" {
+		return nil, fmt.Errorf("%w: missing keyId or signature", ErrInvalidSignature)
+	}
+
+	return params, nil
+}
+
+// readBody returns the content of the request body, restoring it so it can be sent or
+// read again.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+
+		return io.ReadAll(body)
+	}
+
+	content, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+
+	req.Body = io.NopCloser(bytes.NewReader(content))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+
+	return content, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,179 @@
+package form3client_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	f3Client "form3-client-library"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRSAKeyPEM(t *testing.T) (private, public []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+}
+
+func newEd25519KeyPEM(t *testing.T) (private, public []byte) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+}
+
+func TestRequestSigner_WhenRequestsSigned_ThenVerifierAcceptsThem(t *testing.T) {
+	tests := []struct {
+		name         string
+		keys         func(t *testing.T) ([]byte, []byte)
+		expAlgorithm string
+	}{
+		{"rsa", newRSAKeyPEM, `algorithm="rsa-sha256"`},
+		{"ed25519", newEd25519KeyPEM, `algorithm="ed25519"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privatePEM, publicPEM := tt.keys(t)
+
+			privateKey, err := f3Client.ParsePrivateKeyPEM(privatePEM)
+			require.NoError(t, err)
+
+			publicKey, err := f3Client.ParsePublicKeyPEM(publicPEM)
+			require.NoError(t, err)
+
+			var (
+				verifyErrs     []error
+				authorizations []string
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorizations = append(authorizations, r.Header.Get("Authorization"))
+				verifyErrs = append(verifyErrs, f3Client.VerifyRequestSignature(r, func(keyID string) (crypto.PublicKey, error) {
+					assert.Equal(t, "key-1", keyID)
+					return publicKey, nil
+				}))
+
+				if r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusCreated)
+				}
+				_, _ = w.Write([]byte(`{"data":{"id":"test-id"}}`))
+			}))
+			defer server.Close()
+
+			clock := &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+			signer, err := f3Client.NewRequestSigner("key-1", privateKey, f3Client.SignerClock(clock))
+			require.NoError(t, err)
+
+			c := f3Client.NewClient(f3Client.BaseURL(server.URL), f3Client.WithMiddleware(signer.Middleware()))
+
+			_, err = c.Create(context.Background(), newGBAccountRequest())
+			assert.NoError(t, err)
+
+			_, err = c.Fetch(context.Background(), "test-id")
+			assert.NoError(t, err)
+
+			assert.Equal(t, []error{nil, nil}, verifyErrs)
+			assert.Contains(t, authorizations[0], tt.expAlgorithm)
+			assert.Contains(t, authorizations[0], `headers="(request-target) host date digest content-length"`)
+			assert.Contains(t, authorizations[1], `headers="(request-target) host date"`)
+		})
+	}
+}
+
+func TestVerifyRequestSignature_WhenRequestTampered_ThenErrInvalidSignature(t *testing.T) {
+	privatePEM, publicPEM := newEd25519KeyPEM(t)
+
+	privateKey, err := f3Client.ParsePrivateKeyPEM(privatePEM)
+	require.NoError(t, err)
+
+	publicKey, err := f3Client.ParsePublicKeyPEM(publicPEM)
+	require.NoError(t, err)
+
+	signer, err := f3Client.NewRequestSigner("key-1", privateKey)
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://accountapi:8080/v1/organisation/accounts", strings.NewReader(`{"data":{}}`))
+	signedReq, err := signer.Sign(req)
+	require.NoError(t, err)
+
+	keys := func(keyID string) (crypto.PublicKey, error) { return publicKey, nil }
+	assert.NoError(t, f3Client.VerifyRequestSignature(signedReq, keys))
+
+	tampered := signedReq.Clone(context.Background())
+	tampered.Body, tampered.GetBody = http.NoBody, nil
+	tampered.Header.Set("Digest", "SHA-256=tampered")
+	tampered.Method = http.MethodDelete
+	assert.ErrorIs(t, f3Client.VerifyRequestSignature(tampered, keys), f3Client.ErrInvalidSignature)
+
+	tampered, err = signer.Sign(req)
+	require.NoError(t, err)
+	tampered.Body, tampered.GetBody = io.NopCloser(strings.NewReader(`{"data":{"id":1}}`)), nil
+	assert.ErrorIs(t, f3Client.VerifyRequestSignature(tampered, keys), f3Client.ErrInvalidSignature)
+
+	unsigned, _ := http.NewRequest(http.MethodGet, "http://accountapi:8080/v1/organisation/accounts", nil)
+	assert.ErrorIs(t, f3Client.VerifyRequestSignature(unsigned, keys), f3Client.ErrInvalidSignature)
+}
+
+func TestRequestSigner_WhenBodyWithoutGetBody_ThenBothRequestsKeepTheBody(t *testing.T) {
+	privatePEM, publicPEM := newEd25519KeyPEM(t)
+
+	privateKey, err := f3Client.ParsePrivateKeyPEM(privatePEM)
+	require.NoError(t, err)
+
+	publicKey, err := f3Client.ParsePublicKeyPEM(publicPEM)
+	require.NoError(t, err)
+
+	signer, err := f3Client.NewRequestSigner("key-1", privateKey)
+	require.NoError(t, err)
+
+	const body = `{"data":{"id":"test-id"}}`
+
+	req, _ := http.NewRequest(http.MethodPost, "http://accountapi:8080/v1/organisation/accounts", nil)
+	req.Body, req.ContentLength = io.NopCloser(strings.NewReader(body)), int64(len(body))
+
+	signedReq, err := signer.Sign(req)
+	require.NoError(t, err)
+
+	assert.NoError(t, f3Client.VerifyRequestSignature(signedReq, func(keyID string) (crypto.PublicKey, error) {
+		return publicKey, nil
+	}))
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	signedBody, err := io.ReadAll(signedReq.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(signedBody))
+
+	originalBody, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(originalBody))
+}
+
+func TestParsePrivateKeyPEM_WhenInvalid_ThenErrUnsupportedKey(t *testing.T) {
+	_, err := f3Client.ParsePrivateKeyPEM([]byte("not a key"))
+	assert.ErrorIs(t, err, f3Client.ErrUnsupportedKey)
+}
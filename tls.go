@@ -0,0 +1,86 @@
+package form3client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// withTLS applies the configure func to a copy of the Client TLS configuration and
+// installs it in a copy of the default transport, keeping the first failure in c.err.
+func withTLS(c Client, configure func(config *tls.Config) error) Client {
+	if c.err != nil {
+		return c
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.tlsConfig != nil {
+		config = c.tlsConfig.Clone()
+	}
+
+	if err := configure(config); err != nil {
+		c.err = fmt.Errorf("%w: %w", ErrTLSConfig, err)
+		return c
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+
+	c.tlsConfig = config
+	c.client.Transport = transport
+	return c
+}
+
+// certificateReloader loads a client certificate from files, reloading it when their
+// modification time changes.
+type certificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mu          sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+// GetClientCertificate implements tls.Config.GetClientCertificate.
+func (r *certificateReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.reload(); err != nil && r.cert == nil {
+		return nil, err
+	}
+
+	return r.cert, nil
+}
+
+// reload loads the certificate if the files changed since the last load, the caller
+// must hold r.mu unless the reloader is not shared yet.
+func (r *certificateReloader) reload() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return err
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	if r.cert != nil && certInfo.ModTime().Equal(r.certModTime) && keyInfo.ModTime().Equal(r.keyModTime) {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.cert = &cert
+	r.certModTime, r.keyModTime = certInfo.ModTime(), keyInfo.ModTime()
+	return nil
+}
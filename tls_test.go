@@ -0,0 +1,217 @@
+package form3client_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCertificate returns a client certificate for the commonName signed by the
+// parent, or a self-signed certificate authority when parent is nil.
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	issuer, issuerKey := template, key
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		issuer, issuerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// newMutualTLSServer returns a TLS server requiring client certificates signed by the ca,
+// which records the common name of every client and closes the connection, so each
+// request makes a new handshake.
+func newMutualTLSServer(t *testing.T, ca testCertificate, commonNames *[]string) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*commonNames = append(*commonNames, r.TLS.PeerCertificates[0].Subject.CommonName)
+
+		w.Header().Set("Connection", "close")
+		_, _ = w.Write([]byte(`{"data":{"id":"test-id"}}`))
+	}))
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func serverCertificatePEM(server *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
+
+func TestRootCAs_WhenServerCertificateTrusted_ThenRequestSucceeds(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"id":"test-id"}}`))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, serverCertificatePEM(server), 0o600))
+
+	untrusted := f3Client.NewClient(f3Client.BaseURL(server.URL), f3Client.Retries(0, 0, 0))
+	_, err := untrusted.Fetch(context.Background(), uuid.NewString())
+	assert.Error(t, err)
+
+	for _, option := range []f3Client.ClientOption{
+		f3Client.RootCAs(serverCertificatePEM(server)),
+		f3Client.RootCAsFromFile(caFile),
+	} {
+		c := f3Client.NewClient(f3Client.BaseURL(server.URL), option)
+
+		account, err := c.Fetch(context.Background(), uuid.NewString())
+		assert.NoError(t, err)
+		assert.Equal(t, "test-id", account.ID)
+	}
+}
+
+func TestClientCertificate_WhenServerRequiresIt_ThenRequestSucceeds(t *testing.T) {
+	var (
+		commonNames []string
+
+		ca     = newTestCertificate(t, "test-ca", nil)
+		client = newTestCertificate(t, "client-1", &ca)
+		server = newMutualTLSServer(t, ca, &commonNames)
+	)
+
+	withoutCert := f3Client.NewClient(f3Client.BaseURL(server.URL), f3Client.Retries(0, 0, 0),
+		f3Client.RootCAs(serverCertificatePEM(server)))
+	_, err := withoutCert.Fetch(context.Background(), uuid.NewString())
+	assert.Error(t, err)
+
+	c := f3Client.NewClient(f3Client.BaseURL(server.URL),
+		f3Client.RootCAs(serverCertificatePEM(server)),
+		f3Client.ClientCertificate(client.certPEM, client.keyPEM),
+		f3Client.MinTLSVersion(tls.VersionTLS13))
+	_, err = c.Fetch(context.Background(), uuid.NewString())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"client-1"}, commonNames)
+}
+
+func TestClientCertificateFromFiles_WhenFilesChange_ThenPresentsNewCertificate(t *testing.T) {
+	var (
+		commonNames []string
+
+		ca     = newTestCertificate(t, "test-ca", nil)
+		server = newMutualTLSServer(t, ca, &commonNames)
+
+		dir      = t.TempDir()
+		certFile = filepath.Join(dir, "client.pem")
+		keyFile  = filepath.Join(dir, "client-key.pem")
+	)
+
+	writeCertificate := func(cert testCertificate, modTime time.Time) {
+		require.NoError(t, os.WriteFile(certFile, cert.certPEM, 0o600))
+		require.NoError(t, os.WriteFile(keyFile, cert.keyPEM, 0o600))
+		require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+		require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+	}
+
+	writeCertificate(newTestCertificate(t, "client-1", &ca), time.Now())
+
+	c := f3Client.NewClient(f3Client.BaseURL(server.URL),
+		f3Client.RootCAs(serverCertificatePEM(server)),
+		f3Client.ClientCertificateFromFiles(certFile, keyFile))
+
+	_, err := c.Fetch(context.Background(), uuid.NewString())
+	assert.NoError(t, err)
+
+	_, err = c.Fetch(context.Background(), uuid.NewString())
+	assert.NoError(t, err)
+
+	writeCertificate(newTestCertificate(t, "client-2", &ca), time.Now().Add(time.Minute))
+
+	_, err = c.Fetch(context.Background(), uuid.NewString())
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"client-1", "client-1", "client-2"}, commonNames)
+}
+
+func TestTLSOptions_WhenInvalid_ThenCallsReturnErrTLSConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		option f3Client.ClientOption
+	}{
+		{"root CAs without certificates", f3Client.RootCAs([]byte("not a certificate"))},
+		{"missing root CAs file", f3Client.RootCAsFromFile(filepath.Join(t.TempDir(), "missing.pem"))},
+		{"invalid client certificate", f3Client.ClientCertificate([]byte("cert"), []byte("key"))},
+		{"missing client certificate files", f3Client.ClientCertificateFromFiles("missing.pem", "missing-key.pem")},
+		{"unknown TLS version", f3Client.MinTLSVersion(0x0999)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+
+			c := f3Client.NewClient(tt.option, f3Client.MockDoer(func(client http.Client, req *http.Request) (*http.Response, error) {
+				requests++
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			}))
+
+			_, err := c.Fetch(context.Background(), uuid.NewString())
+			assert.ErrorIs(t, err, f3Client.ErrTLSConfig)
+
+			_, err = c.Create(context.Background(), newGBAccountRequest())
+			assert.ErrorIs(t, err, f3Client.ErrTLSConfig)
+
+			assert.Zero(t, requests)
+		})
+	}
+}
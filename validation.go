@@ -0,0 +1,254 @@
+package form3client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"form3-client-library/iban"
+)
+
+// FieldError is the failure of a single field of a request, identified by its JSON path.
+//
+// Rule is the kind of check that failed, i.e. required, pattern or enum, and may be empty
+// when it can't be inferred from a server message.
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// FieldErrors is the list of every field that failed the validation of a request,
+// it matches ErrInvalidAccount with errors.Is.
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Is reports whether target is ErrInvalidAccount.
+func (e FieldErrors) Is(target error) bool {
+	return target == ErrInvalidAccount
+}
+
+// countryRule describes the account identification accepted by the API for a country,
+// please see https://www.api-docs.form3.tech/api/schemes/fps-direct/accounts/accounts
+type countryRule struct {
+	bankIDCode     string
+	bankID         *regexp.Regexp
+	bankIDRequired bool
+	bicRequired    bool
+	accountNumber  *regexp.Regexp
+	ibanSupported  bool
+}
+
+var countryRules = map[Country]countryRule{
+	CountryAustralia: {
+		bankIDCode: "AUBSB", bankID: digits(6), bicRequired: true,
+		accountNumber: regexp.MustCompile(`^[0-9]{6,10}$`),
+	},
+	CountryBelgium: {
+		bankIDCode: "BE", bankID: digits(3), bankIDRequired: true,
+		accountNumber: digits(7), ibanSupported: true,
+	},
+	CountryCanada: {
+		bankIDCode: "CACPA", bankID: regexp.MustCompile(`^0[0-9]{8}$`), bicRequired: true,
+		accountNumber: regexp.MustCompile(`^[0-9]{7,12}$`),
+	},
+	CountryFrance: {
+		bankIDCode: "FR", bankID: regexp.MustCompile(`^[0-9A-Z]{10}$`), bankIDRequired: true,
+		accountNumber: regexp.MustCompile(`^[0-9A-Z]{11}$`), ibanSupported: true,
+	},
+	CountryGermany: {
+		bankIDCode: "DEBLZ", bankID: digits(8), bankIDRequired: true,
+		accountNumber: regexp.MustCompile(`^[0-9]{1,10}$`), ibanSupported: true,
+	},
+	CountryGreece: {
+		bankIDCode: "GRBIC", bankID: digits(7), bankIDRequired: true,
+		accountNumber: digits(16), ibanSupported: true,
+	},
+	CountryHongKong: {
+		bankIDCode: "HKNCC", bankID: digits(3), bicRequired: true,
+		accountNumber: regexp.MustCompile(`^[0-9]{9,12}$`),
+	},
+	CountryItaly: {
+		bankIDCode: "ITNCC", bankID: regexp.MustCompile(`^[0-9]{10,11}$`), bankIDRequired: true,
+		accountNumber: digits(12), ibanSupported: true,
+	},
+	CountryLuxembourg: {
+		bankIDCode: "LULUX", bankID: digits(3), bankIDRequired: true,
+		accountNumber: regexp.MustCompile(`^[0-9A-Z]{13}$`), ibanSupported: true,
+	},
+	CountryNetherlands: {
+		bicRequired:   true,
+		accountNumber: digits(10), ibanSupported: true,
+	},
+	CountryPoland: {
+		bankIDCode: "PLKNR", bankID: digits(8), bankIDRequired: true,
+		accountNumber: digits(16), ibanSupported: true,
+	},
+	CountryPortugal: {
+		bankIDCode: "PTNCC", bankID: digits(8), bankIDRequired: true,
+		accountNumber: digits(11), ibanSupported: true,
+	},
+	CountrySpain: {
+		bankIDCode: "ESNCC", bankID: digits(8), bankIDRequired: true,
+		accountNumber: digits(10), ibanSupported: true,
+	},
+	CountrySwitzerland: {
+		bankIDCode: "CHBCC", bankID: digits(5), bankIDRequired: true,
+		accountNumber: regexp.MustCompile(`^[0-9A-Z]{12}$`), ibanSupported: true,
+	},
+	CountryUnitedKingdom: {
+		bankIDCode: "GBDSC", bankID: digits(6), bankIDRequired: true, bicRequired: true,
+		accountNumber: digits(8), ibanSupported: true,
+	},
+	CountryUnitedStates: {
+		bankIDCode: "USABA", bankID: digits(9), bankIDRequired: true, bicRequired: true,
+		accountNumber: regexp.MustCompile(`^[0-9A-Z]{6,17}$`),
+	},
+}
+
+func digits(length int) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(`^[0-9]{%d}$`, length))
+}
+
+// ValidateAccountRequest checks an account to be created against the rules of its
+// country, i.e. a GB account requires a 6 digits bank_id with the GBDSC bank_id_code,
+// a BIC and an 8 digits account number when provided.
+//
+// Countries without specific rules are only checked for the required attributes, while
+// a provided IBAN or BIC is always checked with the iban package.
+//
+// If any field is invalid FieldErrors is returned with every failure.
+func ValidateAccountRequest(account AccountRequest) error {
+	var errs FieldErrors
+
+	if containsOnlyBlanks(account.ID) {
+		errs = append(errs, FieldError{"id", "required", "is required"})
+	}
+
+	if containsOnlyBlanks(account.OrganisationID) {
+		errs = append(errs, FieldError{"organisation_id", "required", "is required"})
+	}
+
+	if account.Attributes == nil {
+		errs = append(errs, FieldError{"attributes", "required", "is required"})
+		return errs
+	}
+
+	errs = append(errs, validateAttributes(*account.Attributes)...)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func validateAttributes(a AccountAttributesRequest) FieldErrors {
+	var errs FieldErrors
+
+	switch {
+	case a.Country == "":
+		errs = append(errs, FieldError{"attributes.country", "required", "is required"})
+	case !a.Country.Valid():
+		errs = append(errs, FieldError{"attributes.country", "enum", fmt.Sprintf("%q is not an ISO 3166 country code", a.Country)})
+	}
+
+	if len(a.Name) == 0 || containsOnlyBlanks(strings.Join(a.Name, "")) {
+		errs = append(errs, FieldError{"attributes.name", "required", "is required"})
+	}
+
+	if a.Bic != "" {
+		if err := iban.ValidateBIC(a.Bic); err != nil {
+			errs = append(errs, FieldError{"attributes.bic", "format", err.Error()})
+		}
+	}
+
+	if a.Iban != "" {
+		errs = append(errs, validateIban(a)...)
+	}
+
+	rule, ok := countryRules[a.Country]
+	if !ok {
+		return errs
+	}
+
+	switch {
+	case a.BankID == "" && rule.bankIDRequired:
+		errs = append(errs, FieldError{"attributes.bank_id", "required", fmt.Sprintf("is required for country %s", a.Country)})
+	case a.BankID != "" && rule.bankID == nil:
+		errs = append(errs, FieldError{"attributes.bank_id", "unsupported", fmt.Sprintf("is not supported for country %s", a.Country)})
+	case a.BankID != "" && !rule.bankID.MatchString(a.BankID):
+		errs = append(errs, FieldError{"attributes.bank_id", "pattern", fmt.Sprintf("%q doesn't match %s for country %s", a.BankID, rule.bankID, a.Country)})
+	}
+
+	switch {
+	case a.BankIDCode == "" && rule.bankIDCode != "" && (a.BankID != "" || rule.bankIDRequired):
+		errs = append(errs, FieldError{"attributes.bank_id_code", "required", fmt.Sprintf("must be %s for country %s", rule.bankIDCode, a.Country)})
+	case a.BankIDCode != "" && a.BankIDCode != rule.bankIDCode:
+		errs = append(errs, FieldError{"attributes.bank_id_code", "enum", fmt.Sprintf("%q must be %q for country %s", a.BankIDCode, rule.bankIDCode, a.Country)})
+	}
+
+	if a.Bic == "" && rule.bicRequired {
+		errs = append(errs, FieldError{"attributes.bic", "required", fmt.Sprintf("is required for country %s", a.Country)})
+	}
+
+	if a.AccountNumber != "" && !rule.accountNumber.MatchString(a.AccountNumber) {
+		errs = append(errs, FieldError{"attributes.account_number", "pattern", fmt.Sprintf("%q doesn't match %s for country %s", a.AccountNumber, rule.accountNumber, a.Country)})
+	}
+
+	if a.Iban != "" && !rule.ibanSupported {
+		errs = append(errs, FieldError{"attributes.iban", "unsupported", fmt.Sprintf("is not supported for country %s", a.Country)})
+	}
+
+	return errs
+}
+
+// validateIban checks the IBAN checksum and structure, and that it belongs to the
+// country, bank ID and account number of the account when they are provided.
+func validateIban(a AccountAttributesRequest) FieldErrors {
+	parsed, err := iban.Parse(a.Iban)
+	if err != nil {
+		return FieldErrors{{"attributes.iban", "format", err.Error()}}
+	}
+
+	var errs FieldErrors
+
+	if a.Country != "" && parsed.CountryCode != string(a.Country) {
+		errs = append(errs, FieldError{"attributes.iban", "mismatch", fmt.Sprintf("country %s doesn't match country %s", parsed.CountryCode, a.Country)})
+	}
+
+	if bankID := parsed.BankID(); a.BankID != "" && bankID != "" && bankID != a.BankID {
+		errs = append(errs, FieldError{"attributes.iban", "mismatch", fmt.Sprintf("bank id %s doesn't match bank_id %s", bankID, a.BankID)})
+	}
+
+	if accountNumber := parsed.AccountNumber(); a.AccountNumber != "" && accountNumber != "" && accountNumber != padAccountNumber(a.AccountNumber, len(accountNumber)) {
+		errs = append(errs, FieldError{"attributes.iban", "mismatch", fmt.Sprintf("account number %s doesn't match account_number %s", accountNumber, a.AccountNumber)})
+	}
+
+	return errs
+}
+
+// padAccountNumber left pads a numeric national account number with zeros to the length
+// of the account number of an IBAN, i.e. the 10 digits of a DE IBAN.
+func padAccountNumber(accountNumber string, length int) string {
+	if len(accountNumber) >= length || strings.Trim(accountNumber, "0123456789") != "" {
+		return accountNumber
+	}
+
+	return strings.Repeat("0", length-len(accountNumber)) + accountNumber
+}
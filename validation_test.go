@@ -0,0 +1,209 @@
+package form3client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	f3Client "form3-client-library"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGBAccountRequest() f3Client.AccountRequest {
+	return f3Client.AccountRequest{
+		ID:             uuid.NewString(),
+		OrganisationID: uuid.NewString(),
+		Type:           "accounts",
+		Attributes: &f3Client.AccountAttributesRequest{
+			Country:       f3Client.CountryUnitedKingdom,
+			BankID:        "400300",
+			BankIDCode:    "GBDSC",
+			Bic:           "NWBKGB22",
+			AccountNumber: "41426819",
+			Name:          []string{"Samantha Holder"},
+		},
+	}
+}
+
+func TestValidateAccountRequest_WhenValidGBAccount_ThenNoErr(t *testing.T) {
+	assert.NoError(t, f3Client.ValidateAccountRequest(newGBAccountRequest()))
+}
+
+func TestValidateAccountRequest_WhenInvalidGBAccount_ThenReturnsEveryField(t *testing.T) {
+	req := newGBAccountRequest()
+	req.Attributes.BankID = "4003"
+	req.Attributes.BankIDCode = "DEBLZ"
+	req.Attributes.Bic = ""
+	req.Attributes.AccountNumber = "414268"
+
+	err := f3Client.ValidateAccountRequest(req)
+
+	var fieldErrs f3Client.FieldErrors
+	assert.ErrorIs(t, err, f3Client.ErrInvalidAccount)
+	assert.True(t, errors.As(err, &fieldErrs))
+
+	var fields []string
+	for _, fieldErr := range fieldErrs {
+		fields = append(fields, fieldErr.Field)
+	}
+
+	assert.Equal(t, []string{
+		"attributes.bank_id",
+		"attributes.bank_id_code",
+		"attributes.bic",
+		"attributes.account_number",
+	}, fields)
+}
+
+func TestValidateAccountRequest_WhenCountryRules_ThenChecksCountrySpecificFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes f3Client.AccountAttributesRequest
+		expErr     string
+	}{
+		{
+			name:       "DE requires bank id",
+			attributes: f3Client.AccountAttributesRequest{Country: f3Client.CountryGermany, Name: []string{"name"}},
+			expErr:     "attributes.bank_id is required for country DE; attributes.bank_id_code must be DEBLZ for country DE",
+		},
+		{
+			name: "DE valid",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryGermany, BankID: "37040044", BankIDCode: "DEBLZ", Name: []string{"name"},
+			},
+		},
+		{
+			name: "DE valid account number",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryGermany, BankID: "37040044", BankIDCode: "DEBLZ", AccountNumber: "0532013000", Name: []string{"name"},
+			},
+		},
+		{
+			name: "DE account number too long",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryGermany, BankID: "37040044", BankIDCode: "DEBLZ", AccountNumber: "05320130001", Name: []string{"name"},
+			},
+			expErr: `attributes.account_number "05320130001" doesn't match ^[0-9]{1,10}$ for country DE`,
+		},
+		{
+			name: "FR valid account number",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryFrance, BankID: "2004101005", BankIDCode: "FR", AccountNumber: "0500013M026", Name: []string{"name"},
+			},
+		},
+		{
+			name: "FR account number without 11 characters",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryFrance, BankID: "2004101005", BankIDCode: "FR", AccountNumber: "0500013M02", Name: []string{"name"},
+			},
+			expErr: `attributes.account_number "0500013M02" doesn't match ^[0-9A-Z]{11}$ for country FR`,
+		},
+		{
+			name: "DE iban and account number",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryGermany, BankID: "37040044", BankIDCode: "DEBLZ", AccountNumber: "0532013000",
+				Iban: "DE89370400440532013000", Name: []string{"name"},
+			},
+		},
+		{
+			name: "DE iban and account number without leading zeros",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryGermany, BankID: "37040044", BankIDCode: "DEBLZ", AccountNumber: "532013000",
+				Iban: "DE89370400440532013000", Name: []string{"name"},
+			},
+		},
+		{
+			name: "DE iban of another account number",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryGermany, BankID: "37040044", BankIDCode: "DEBLZ", AccountNumber: "5320130",
+				Iban: "DE89370400440532013000", Name: []string{"name"},
+			},
+			expErr: "attributes.iban account number 0532013000 doesn't match account_number 5320130",
+		},
+		{
+			name: "FR iban and account number",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryFrance, BankID: "2004101005", BankIDCode: "FR", AccountNumber: "0500013M026",
+				Iban: "FR1420041010050500013M02606", Name: []string{"name"},
+			},
+		},
+		{
+			name: "US doesn't support iban",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryUnitedStates, BankID: "021000021", BankIDCode: "USABA", Bic: "CHASUS33",
+				Iban: "US00", Name: []string{"name"},
+			},
+			expErr: `attributes.iban invalid IBAN format: "US00"; attributes.iban is not supported for country US`,
+		},
+		{
+			name: "GB iban of another bank",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryUnitedKingdom, BankID: "400300", BankIDCode: "GBDSC", Bic: "NWBKGB22",
+				Iban: "GB82 WEST 1234 5698 7654 32", Name: []string{"name"},
+			},
+			expErr: "attributes.iban bank id 123456 doesn't match bank_id 400300",
+		},
+		{
+			name: "GB valid iban",
+			attributes: f3Client.AccountAttributesRequest{
+				Country: f3Client.CountryUnitedKingdom, BankID: "123456", BankIDCode: "GBDSC", Bic: "WESTGB22",
+				AccountNumber: "98765432", Iban: "GB82WEST12345698765432", Name: []string{"name"},
+			},
+		},
+		{
+			name:       "country without rules",
+			attributes: f3Client.AccountAttributesRequest{Country: "AR", Name: []string{"name"}},
+		},
+		{
+			name:       "unknown country",
+			attributes: f3Client.AccountAttributesRequest{Country: "UK"},
+			expErr:     `attributes.country "UK" is not an ISO 3166 country code; attributes.name is required`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := tt.attributes
+			err := f3Client.ValidateAccountRequest(f3Client.AccountRequest{
+				ID:             uuid.NewString(),
+				OrganisationID: uuid.NewString(),
+				Attributes:     &attributes,
+			})
+
+			if tt.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
+
+func TestValidateRequests_WhenInvalidAccount_ThenCreateFailsBeforeSending(t *testing.T) {
+	var (
+		calls int
+
+		doerMockFunc = func(client http.Client, req *http.Request) (resp *http.Response, err error) {
+			calls++
+			return &http.Response{StatusCode: http.StatusCreated, Body: http.NoBody}, nil
+		}
+
+		c = f3Client.NewClient(f3Client.MockDoer(doerMockFunc), f3Client.ValidateRequests())
+	)
+
+	req := newGBAccountRequest()
+	req.Attributes.Name = nil
+
+	_, err := c.Create(context.TODO(), req)
+
+	assert.ErrorIs(t, err, f3Client.ErrInvalidAccount)
+	assert.Equal(t, 0, calls)
+
+	_, err = c.Create(context.TODO(), newGBAccountRequest())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}